@@ -0,0 +1,88 @@
+package life
+
+// WithCellAges tracks how many generations in a row each cell has been
+// alive, counting a newly born cell, and every live cell of the first
+// generation, as one generation old. The ages carry over to each successive
+// generation produced by Next, NextWith, NextIncremental, Step and StepStats,
+// and through edits made with Set, Paint, Stamp, AddPattern and Resize, and
+// are read with OldestCell.
+func WithCellAges() Option {
+	return func(g *Generation) {
+		g.trackAges = true
+	}
+}
+
+// OldestCell returns the position and age of the live cell which has been
+// alive for the most generations in a row. Ties go to the first such cell in index
+// order, so on a still life every cell is as old as the first. The final
+// return value is false when there are no live cells or the generation was
+// not created with WithCellAges.
+func (g *Generation) OldestCell() (x, y, age int, ok bool) {
+	if g.ages == nil {
+		return 0, 0, 0, false
+	}
+
+	oldest := -1
+	for i, a := range g.ages {
+		if a > age && g.cells[i].Alive() {
+			oldest, age = i, a
+		}
+	}
+	if oldest == -1 {
+		return 0, 0, 0, false
+	}
+
+	return oldest % g.dimensions.X, oldest / g.dimensions.X, age, true
+}
+
+// setCell replaces the cell at idx with c in place, keeping any ages in step:
+// a cell which comes alive is one generation old, one which dies has no age,
+// and one which stays alive keeps its age
+func (g *Generation) setCell(idx int, c Cell) {
+	if g.ages != nil {
+		switch {
+		case !c.Alive():
+			g.ages[idx] = 0
+		case !g.cells[idx].Alive():
+			g.ages[idx] = 1
+		}
+	}
+	g.cells[idx] = c
+}
+
+// startAges returns the ages of the cells of a first generation
+func startAges(cells []Cell) []int {
+	ages := make([]int, len(cells))
+	for i, c := range cells {
+		if c.Alive() {
+			ages[i] = 1
+		}
+	}
+
+	return ages
+}
+
+// aged gives next, the generation after g, the ages of its cells: one more
+// than in g for cells which stayed alive, and one for those just born. Ages
+// are only tracked for generations created with WithCellAges.
+func (g *Generation) aged(next *Generation) *Generation {
+	if !g.trackAges {
+		return next
+	}
+	if g.ages == nil || len(g.ages) != len(next.cells) {
+		next.ages = startAges(next.cells)
+		return next
+	}
+
+	next.ages = make([]int, len(next.cells))
+	for i, c := range next.cells {
+		if c.Alive() {
+			next.ages[i] = g.ages[i] + 1
+			if !g.cells[i].Alive() {
+				next.ages[i] = 1
+			}
+		}
+	}
+
+	return next
+}
@@ -0,0 +1,92 @@
+package life_test
+
+import (
+	"testing"
+
+	"github.com/enocom/life"
+)
+
+func TestOldestCell(t *testing.T) {
+	// a block beside a blinker: the block's cells keep ageing while the
+	// blinker's ends are reborn every generation
+	d := life.Dimension{X: 8, Y: 5}
+	g := life.NewGeneration(
+		life.WithDimension(d),
+		life.WithCells(newBoard(d,
+			[2]int{5, 1}, [2]int{5, 2}, [2]int{5, 3},
+			[2]int{0, 0}, [2]int{1, 0}, [2]int{0, 1}, [2]int{1, 1},
+		).Cells()),
+		life.WithCellAges(),
+	)
+
+	if x, y, age, ok := g.OldestCell(); !ok || x != 0 || y != 0 || age != 1 {
+		t.Errorf("want: (0, 0) aged 1, got: (%v, %v) aged %v (ok = %v)", x, y, age, ok)
+	}
+
+	for i := 0; i < 3; i++ {
+		g = life.Next(g)
+	}
+	if x, y, age, ok := g.OldestCell(); !ok || x != 0 || y != 0 || age != 4 {
+		t.Errorf("want: (0, 0) aged 4, got: (%v, %v) aged %v (ok = %v)", x, y, age, ok)
+	}
+
+	// the block is gone, leaving the blinker's centre as the oldest cell
+	for _, p := range [][2]int{{0, 0}, {1, 0}, {0, 1}, {1, 1}} {
+		if err := g.Set(p[0], p[1], false); err != nil {
+			t.Fatal(err)
+		}
+	}
+	g = life.Next(g)
+	if x, y, age, ok := g.OldestCell(); !ok || x != 5 || y != 2 || age != 5 {
+		t.Errorf("want: (5, 2) aged 5, got: (%v, %v) aged %v (ok = %v)", x, y, age, ok)
+	}
+}
+
+func TestCellAgesAcrossEdits(t *testing.T) {
+	d := life.Dimension{X: 8, Y: 5}
+	g := life.NewGeneration(
+		life.WithDimension(d),
+		life.WithCells(newBoard(d,
+			[2]int{5, 1}, [2]int{5, 2}, [2]int{5, 3},
+			[2]int{0, 0}, [2]int{1, 0}, [2]int{0, 1}, [2]int{1, 1},
+		).Cells()),
+		life.WithCellAges(),
+	)
+	g, _ = life.Step(g)
+	g, _, _ = life.StepStats(g)
+	if x, y, age, ok := g.OldestCell(); !ok || x != 0 || y != 0 || age != 3 {
+		t.Errorf("want: (0, 0) aged 3 after Step and StepStats, got: (%v, %v) aged %v (ok = %v)", x, y, age, ok)
+	}
+
+	// a cleared cell is no longer the oldest, even before the next step
+	for _, p := range [][2]int{{0, 0}, {1, 0}, {0, 1}, {1, 1}} {
+		if err := g.Set(p[0], p[1], false); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if x, y, age, ok := g.OldestCell(); !ok || x != 5 || y != 2 || age != 3 {
+		t.Errorf("want: (5, 2) aged 3, got: (%v, %v) aged %v (ok = %v)", x, y, age, ok)
+	}
+
+	// stamped cells start at one, and resizing keeps what the board had
+	block := newBoard(life.Dimension{X: 2, Y: 2}, [2]int{0, 0}, [2]int{1, 0}, [2]int{0, 1}, [2]int{1, 1})
+	if err := g.Stamp(block, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+	g = life.Next(g.Resize(life.Dimension{X: 10, Y: 6}))
+	if x, y, age, ok := g.OldestCell(); !ok || x != 5 || y != 2 || age != 4 {
+		t.Errorf("want: (5, 2) aged 4, got: (%v, %v) aged %v (ok = %v)", x, y, age, ok)
+	}
+}
+
+func TestOldestCellUntracked(t *testing.T) {
+	g := newBoard(life.Dimension{X: 3, Y: 3}, [2]int{1, 1})
+	if _, _, _, ok := g.OldestCell(); ok {
+		t.Error("want: no oldest cell without WithCellAges")
+	}
+
+	empty := life.NewGeneration(life.WithDimension(life.Dimension{X: 3, Y: 3}), life.WithCells(make([]life.Cell, 9)), life.WithCellAges())
+	if _, _, _, ok := life.Next(empty).OldestCell(); ok {
+		t.Error("want: no oldest cell on an empty board")
+	}
+}
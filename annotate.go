@@ -0,0 +1,97 @@
+package life
+
+import "strings"
+
+// annotationShapes are the common objects WithAnnotations recognizes, as
+// ParseBoard boards. Objects with more than one distinct shape list each one,
+// named with a "#" suffix which the label leaves out.
+var annotationShapes = map[string]string{
+	"block":    "oo\noo",
+	"beehive":  ".oo.\no..o\n.oo.",
+	"loaf":     ".oo.\no..o\n.o.o\n..o.",
+	"boat":     "oo.\no.o\n.o.",
+	"ship":     "oo.\no.o\n.oo",
+	"tub":      ".o.\no.o\n.o.",
+	"pond":     ".oo.\no..o\no..o\n.oo.",
+	"blinker":  "ooo",
+	"toad#1":   ".ooo\nooo.",
+	"toad#2":   "..o.\no..o\no..o\n.o..",
+	"beacon#1": "oo..\noo..\n..oo\n..oo",
+	"beacon#2": "oo..\no...\n...o\n..oo",
+	"glider#1": ".o.\n..o\nooo",
+	"glider#2": "o.o\n.oo\n.o.",
+}
+
+// annotationLibrary is annotationShapes parsed for Identify
+var annotationLibrary = func() map[string]*Generation {
+	lib := make(map[string]*Generation, len(annotationShapes))
+	for name, shape := range annotationShapes {
+		g, err := ParseBoard(shape)
+		if err != nil {
+			panic(err)
+		}
+		lib[name] = g
+	}
+
+	return lib
+}()
+
+// WithAnnotations labels each common object on the board, such as a block,
+// blinker or glider, with its name, written over the row above the object's
+// bounding box, or the row below it when there is no room above. Finding and
+// identifying every object is expensive, so this suits small boards and
+// teaching rather than large runs. Objects touching other live cells, and
+// those not recognized, are not labelled.
+func WithAnnotations() RenderOption {
+	return func(r *renderer) {
+		r.annotations = true
+	}
+}
+
+// annotate writes the name of each recognized object in the view over rows,
+// the rendered lines of the view
+func (g *Generation) annotate(rows []string, view Rect) {
+	for _, c := range g.clusters() {
+		label, ok := identifyObject(c)
+		if !ok {
+			continue
+		}
+
+		box, _ := c.BoundingBox()
+		y := box.Y - 1
+		if y < view.Y {
+			y = box.Y + box.H
+		}
+		if !view.contains(box.X, y) {
+			continue
+		}
+
+		row := y - view.Y
+		column := 2 * (box.X - view.X)
+		if g.neighborhood == Hexagonal && y%2 == 1 {
+			column++
+		}
+		rows[row] = overwrite(rows[row], column, label)
+	}
+}
+
+// identifyObject names an object if it is one of the common objects in
+// annotationLibrary
+func identifyObject(g *Generation) (string, bool) {
+	name, ok := Identify(g, annotationLibrary)
+	name, _, _ = strings.Cut(name, "#")
+
+	return name, ok
+}
+
+// overwrite replaces the runes of s from position at onwards with text,
+// padding s with spaces if it is too short
+func overwrite(s string, at int, text string) string {
+	runes := []rune(s)
+	for len(runes) < at+len([]rune(text)) {
+		runes = append(runes, ' ')
+	}
+	copy(runes[at:], []rune(text))
+
+	return string(runes)
+}
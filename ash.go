@@ -0,0 +1,23 @@
+package life
+
+// Ash runs seed under rule until it settles, as RunToCompletion does, and
+// returns a census of the objects left behind, the "ash" of soup searches:
+// how many of each connected cluster of live cells are on the final board,
+// e.g. {"block": 3, "blinker": 1}. Common objects are given their usual names
+// and any other cluster its Apgcode. The second return value is false if the
+// board had not settled within maxGen generations, in which case the census
+// is of the board as it stands.
+func Ash(seed *Generation, rule Rule, maxGen int) (map[string]int, bool) {
+	final, _, cls := RunToCompletion(seed, rule, maxGen)
+
+	census := make(map[string]int)
+	for _, c := range final.Components() {
+		name, ok := identifyObject(c)
+		if !ok {
+			name = c.Apgcode()
+		}
+		census[name]++
+	}
+
+	return census, cls != Unsettled
+}
@@ -0,0 +1,60 @@
+package life_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/enocom/life"
+)
+
+func TestAsh(t *testing.T) {
+	seed, err := life.ParseBoard(`
+..........
+.oo.......
+.o........
+..........
+......ooo.
+..........
+.o........
+.oo.......
+.......oo.
+.......oo.
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	census, ok := life.Ash(seed, life.Conway, 20)
+	if !ok {
+		t.Fatal("want: the board to settle")
+	}
+
+	// each L of three cells grows into a block, and the lone block beside
+	// the blinker is already settled
+	want := map[string]int{"block": 3, "blinker": 1}
+	if !reflect.DeepEqual(census, want) {
+		t.Errorf("want: %v, got: %v", want, census)
+	}
+}
+
+func TestAshUnsettled(t *testing.T) {
+	// a line of four cells is still growing towards a beehive after one
+	// generation, so the census falls back to the apgcode of what is there
+	seed, err := life.ParseBoard(`
+........
+.oooo...
+........
+........
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	census, ok := life.Ash(seed, life.Conway, 1)
+	if ok {
+		t.Error("want: the board not to settle in one generation")
+	}
+	if len(census) != 1 {
+		t.Errorf("want: one kind of object, got: %v", census)
+	}
+}
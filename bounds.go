@@ -0,0 +1,114 @@
+package life
+
+// Rect describes a rectangular region of a board. A Rect returned by
+// WrappedBoundingBox may extend past the right or bottom edge of the board, in
+// which case the region continues from the opposite edge.
+type Rect struct {
+	X int
+	Y int
+	W int
+	H int
+}
+
+// BoundingBox returns the smallest rectangle containing every live cell. The
+// second return value is false when there are no live cells.
+func (g *Generation) BoundingBox() (Rect, bool) {
+	cols, rows, ok := g.occupancy()
+	if !ok {
+		return Rect{}, false
+	}
+
+	x, w := span(cols)
+	y, h := span(rows)
+
+	return Rect{X: x, Y: y, W: w, H: h}, true
+}
+
+// Normalize returns the pattern of live cells cropped to its bounding box, so
+// patterns which differ only by their position on the board normalize to the
+// same generation. An empty board normalizes to a generation with no cells
+// and a zero Dimension.
+func (g *Generation) Normalize() *Generation {
+	box, _ := g.BoundingBox()
+
+	return g.crop(box)
+}
+
+// WrappedBoundingBox returns the smallest rectangle containing every live cell
+// when the board is treated as a torus. For each axis both the plain span and
+// the span crossing the wrap seam are considered and the tighter one wins, so a
+// pattern straddling an edge is not reported as covering the whole board. Ties
+// prefer the plain span. The second return value is false when there are no
+// live cells.
+func (g *Generation) WrappedBoundingBox() (Rect, bool) {
+	cols, rows, ok := g.occupancy()
+	if !ok {
+		return Rect{}, false
+	}
+
+	x, w := wrappedSpan(cols)
+	y, h := wrappedSpan(rows)
+
+	return Rect{X: x, Y: y, W: w, H: h}, true
+}
+
+// occupancy reports which columns and which rows hold at least one live cell
+func (g *Generation) occupancy() (cols, rows []bool, ok bool) {
+	cols = make([]bool, g.dimensions.X)
+	rows = make([]bool, g.dimensions.Y)
+
+	for i, c := range g.cells {
+		if !c.Alive() {
+			continue
+		}
+		cols[i%g.dimensions.X] = true
+		rows[i/g.dimensions.X] = true
+		ok = true
+	}
+
+	return cols, rows, ok
+}
+
+// span returns the start and length of the range between the first and last
+// occupied positions
+func span(occupied []bool) (start, length int) {
+	first, last := -1, -1
+	for i, o := range occupied {
+		if !o {
+			continue
+		}
+		if first == -1 {
+			first = i
+		}
+		last = i
+	}
+
+	return first, last - first + 1
+}
+
+// wrappedSpan returns the start and length of the shortest range covering
+// every occupied position on a circular axis. The range is found by locating
+// the longest run of unoccupied positions, which may itself wrap.
+func wrappedSpan(occupied []bool) (start, length int) {
+	n := len(occupied)
+	plainStart, plainLength := span(occupied)
+
+	gap, longest, gapEnd := 0, 0, 0
+	for i := 0; i < 2*n; i++ {
+		if occupied[i%n] {
+			gap = 0
+			continue
+		}
+		gap++
+		if gap > longest && gap < n {
+			longest = gap
+			gapEnd = i % n
+		}
+	}
+
+	if plainLength <= n-longest {
+		return plainStart, plainLength
+	}
+
+	return (gapEnd + 1) % n, n - longest
+}
@@ -0,0 +1,106 @@
+package life_test
+
+import (
+	"testing"
+
+	"github.com/enocom/life"
+)
+
+// newBoard builds a generation of the given size with live cells at the
+// provided (x, y) coordinates
+func newBoard(d life.Dimension, live ...[2]int) *life.Generation {
+	cells := make([]life.Cell, d.X*d.Y)
+	for i := range cells {
+		cells[i] = life.NewDeadCell()
+	}
+	for _, p := range live {
+		cells[p[0]+p[1]*d.X] = life.NewLiveCell()
+	}
+
+	return life.NewGeneration(
+		life.WithDimension(d),
+		life.WithCells(cells),
+	)
+}
+
+func TestBoundingBox(t *testing.T) {
+	g := newBoard(life.Dimension{X: 6, Y: 5}, [2]int{1, 1}, [2]int{3, 2}, [2]int{2, 3})
+
+	got, ok := g.BoundingBox()
+	want := life.Rect{X: 1, Y: 1, W: 3, H: 3}
+	if !ok || got != want {
+		t.Errorf("want: %+v, got: %+v (ok = %v)", want, got, ok)
+	}
+}
+
+func TestBoundingBoxEmpty(t *testing.T) {
+	g := newBoard(life.Dimension{X: 3, Y: 3})
+
+	if _, ok := g.BoundingBox(); ok {
+		t.Error("want: no bounding box for an empty board")
+	}
+	if _, ok := g.WrappedBoundingBox(); ok {
+		t.Error("want: no wrapped bounding box for an empty board")
+	}
+}
+
+func TestWrappedBoundingBox(t *testing.T) {
+	testCases := map[string]struct {
+		live  [][2]int
+		plain life.Rect
+		want  life.Rect
+	}{
+		"no wrap": {
+			live:  [][2]int{{1, 1}, {2, 1}, {3, 1}},
+			plain: life.Rect{X: 1, Y: 1, W: 3, H: 1},
+			want:  life.Rect{X: 1, Y: 1, W: 3, H: 1},
+		},
+		"blinker across left and right edges": {
+			live:  [][2]int{{5, 2}, {0, 2}, {1, 2}},
+			plain: life.Rect{X: 0, Y: 2, W: 6, H: 1},
+			want:  life.Rect{X: 5, Y: 2, W: 3, H: 1},
+		},
+		"pattern across every edge": {
+			// . o . . . .
+			// . . . . . .
+			// . . . . . .
+			// o o . . . .
+			// o . . . . o
+			live:  [][2]int{{1, 0}, {0, 4}, {5, 4}, {0, 3}, {1, 3}},
+			plain: life.Rect{X: 0, Y: 0, W: 6, H: 5},
+			want:  life.Rect{X: 5, Y: 3, W: 3, H: 3},
+		},
+	}
+
+	for description, tc := range testCases {
+		g := newBoard(life.Dimension{X: 6, Y: 5}, tc.live...)
+
+		plain, _ := g.BoundingBox()
+		if plain != tc.plain {
+			t.Errorf("(%s): plain want: %+v, got: %+v", description, tc.plain, plain)
+		}
+
+		got, ok := g.WrappedBoundingBox()
+		if !ok || got != tc.want {
+			t.Errorf("(%s): wrapped want: %+v, got: %+v", description, tc.want, got)
+		}
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	// a glider tucked into the bottom right corner
+	g := newBoard(life.Dimension{X: 7, Y: 6}, [2]int{5, 3}, [2]int{6, 4}, [2]int{4, 5}, [2]int{5, 5}, [2]int{6, 5})
+
+	got := g.Normalize()
+	want := newBoard(life.Dimension{X: 3, Y: 3},
+		[2]int{1, 0}, [2]int{2, 1}, [2]int{0, 2}, [2]int{1, 2}, [2]int{2, 2},
+	)
+	if got.Dimension() != want.Dimension() || !equal(got.Cells(), want.Cells()) {
+		t.Errorf("want: %v, got: %v", want, got)
+	}
+
+	empty := newBoard(life.Dimension{X: 4, Y: 4}).Normalize()
+	if d := (life.Dimension{}); empty.Dimension() != d || len(empty.Cells()) != 0 {
+		t.Errorf("want: a zero dimension generation, got: %v with %v cells", empty.Dimension(), len(empty.Cells()))
+	}
+}
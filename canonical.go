@@ -0,0 +1,308 @@
+package life
+
+import "fmt"
+
+// wechslerDigits encodes a five-cell column of a strip, top cell first
+const wechslerDigits = "0123456789abcdefghijklmnopqrstuv"
+
+// zeroRunDigits encodes the length of a run of four to 39 empty columns
+// after a 'y'
+const zeroRunDigits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+// apgcodeMaxPeriod is the longest period Apgcode looks for
+const apgcodeMaxPeriod = 64
+
+// CanonicalForm returns the pattern of live cells, cropped to its bounding
+// box, in a standard orientation: of its eight rotations and reflections, the
+// one with the smallest extended Wechsler encoding, ordered by length and then
+// lexicographically, as apgsearch does. Patterns which differ only by position,
+// rotation or reflection share a canonical form. An empty generation has a
+// canonical form with no cells.
+func (g *Generation) CanonicalForm() *Generation {
+	box, ok := g.BoundingBox()
+	if !ok {
+		return g.crop(Rect{})
+	}
+
+	best, _ := canonical(g.crop(box))
+
+	return best
+}
+
+// Apgcode returns the apgsearch identifier of the pattern of live cells: a
+// prefix naming the kind of object followed by the extended Wechsler encoding
+// of its canonical form. Still lifes are "xs" followed by their population,
+// e.g. "xs4_33" for the block; oscillators are "xp" and spaceships "xq"
+// followed by their period, e.g. "xp2_7" for the blinker and "xq4_153" for
+// the glider, using the smallest encoding across every phase. A pattern which
+// settles into one of these is identified by what it settles into, while one
+// which does not repeat within 64 generations is reported as "zz_" followed by
+// its own encoding. The pattern evolves on its own in otherwise empty space,
+// ignoring the board's edges.
+func (g *Generation) Apgcode() string {
+	box, ok := g.BoundingBox()
+	if !ok {
+		return "xs0_0"
+	}
+	pattern := g.crop(box)
+
+	phases, moved := pattern.phases(apgcodeMaxPeriod)
+	if phases == nil {
+		_, code := canonical(pattern)
+		return "zz_" + code
+	}
+
+	var code string
+	for _, p := range phases {
+		if _, c := canonical(p); code == "" || wechslerLess(c, code) {
+			code = c
+		}
+	}
+
+	switch {
+	case len(phases) == 1:
+		return fmt.Sprintf("xs%d_%s", phases[0].Population(), code)
+	case moved:
+		return fmt.Sprintf("xq%d_%s", len(phases), code)
+	default:
+		return fmt.Sprintf("xp%d_%s", len(phases), code)
+	}
+}
+
+// Identify names the pattern of live cells in g by finding the library
+// pattern with the same canonical form, so a match may be positioned, rotated
+// or reflected differently and sit on a board of any size. When several
+// library patterns match, the one with the smallest population wins, and then
+// the name which sorts first. The second return value is false when g is
+// empty or nothing in lib matches.
+func Identify(g *Generation, lib map[string]*Generation) (string, bool) {
+	code, ok := canonicalCode(g)
+	if !ok {
+		return "", false
+	}
+
+	var (
+		name       string
+		population int
+		found      bool
+	)
+	for n, p := range lib {
+		if c, ok := canonicalCode(p); !ok || c != code {
+			continue
+		}
+
+		pop := p.Population()
+		if !found || pop < population || (pop == population && n < name) {
+			name, population, found = n, pop, true
+		}
+	}
+
+	return name, found
+}
+
+// canonicalCode returns the extended Wechsler encoding of the canonical form
+// of the live cells, or false for an empty generation
+func canonicalCode(g *Generation) (string, bool) {
+	box, ok := g.BoundingBox()
+	if !ok {
+		return "", false
+	}
+	_, code := canonical(g.crop(box))
+
+	return code, true
+}
+
+// phases evolves a cropped pattern in empty space until it repeats, returning
+// each cropped phase of one period of the cycle it settles into and whether
+// the pattern moves over that period. A nil result means it did not repeat
+// within maxPeriod generations.
+func (g *Generation) phases(maxPeriod int) ([]*Generation, bool) {
+	space := newOpenSpace(g, maxPeriod)
+
+	seen := map[string]int{g.QuadKey(): 0}
+	phases := []*Generation{g}
+	origins := []Rect{{}}
+	for i := 1; i <= maxPeriod; i++ {
+		phase, origin, ok := space.next()
+		if !ok {
+			return nil, false
+		}
+
+		if earlier, ok := seen[phase.QuadKey()]; ok {
+			return phases[earlier:], origin != origins[earlier]
+		}
+		seen[phase.QuadKey()] = i
+		phases = append(phases, phase)
+		origins = append(origins, origin)
+	}
+
+	return nil, false
+}
+
+// openSpace evolves a cropped pattern as if on an endless board of dead
+// cells. The board only keeps a margin around the pattern, and is cropped and
+// padded again whenever the pattern comes near an edge, so its size follows
+// the pattern rather than how long it runs.
+type openSpace struct {
+	board *Generation
+	// x and y give the position of the board's top left cell relative to
+	// the pattern's starting position
+	x, y int
+	// fixed is set for rules with B0, under which empty space does not stay
+	// empty; the board then keeps the margin it starts with, enough for the
+	// generations it will run
+	fixed bool
+}
+
+// newOpenSpace places pattern in empty space, ready to run for up to maxGen
+// generations
+func newOpenSpace(pattern *Generation, maxGen int) *openSpace {
+	s := &openSpace{fixed: pattern.rule.birth[0]}
+	margin := openSpaceMargin(pattern.dimensions)
+	if s.fixed {
+		// the pattern grows by at most one cell on each side per
+		// generation, so it never reaches the edge of this board
+		margin = max(maxGen, 0) + 1
+	}
+	s.board = pattern.padded(margin)
+	s.board.boundary = NewDeadCell()
+	s.board.wrap = false
+	s.x, s.y = -margin, -margin
+
+	return s
+}
+
+// next evolves the pattern by a generation and returns it cropped to its
+// bounding box, along with the box's position relative to where the pattern
+// started. The final return value is false once no live cells remain.
+func (s *openSpace) next() (*Generation, Rect, bool) {
+	s.board = Next(s.board)
+	box, ok := s.board.BoundingBox()
+	if !ok {
+		return nil, Rect{}, false
+	}
+	phase := s.board.crop(box)
+	origin := Rect{X: box.X + s.x, Y: box.Y + s.y}
+
+	d := s.board.dimensions
+	if !s.fixed && (box.X < 1 || box.Y < 1 || box.X+box.W >= d.X || box.Y+box.H >= d.Y) {
+		// crop from an even row, and pad by an even margin, so odd rows stay
+		// odd on hexagonal boards
+		top := box.Y - box.Y%2
+		margin := openSpaceMargin(Dimension{X: box.W, Y: box.H})
+		s.board = s.board.crop(Rect{X: box.X, Y: top, W: box.W, H: box.Y + box.H - top}).padded(margin)
+		s.x += box.X - margin
+		s.y += top - margin
+	}
+
+	return phase, origin, true
+}
+
+// openSpaceMargin returns the margin an openSpace leaves around a pattern of
+// size d: an even number of cells in proportion to the pattern, so a growing
+// pattern is padded again only now and then
+func openSpaceMargin(d Dimension) int {
+	margin := max(d.X, d.Y) + 2
+
+	return margin + margin%2
+}
+
+// padded returns a copy of the generation surrounded by margin dead cells on
+// every side. The padding stands for empty space, so the copy has no mask.
+func (g *Generation) padded(margin int) *Generation {
+	d := Dimension{X: g.dimensions.X + 2*margin, Y: g.dimensions.Y + 2*margin}
+	cells := make([]Cell, d.X*d.Y)
+	for y := 0; y < g.dimensions.Y; y++ {
+		for x := 0; x < g.dimensions.X; x++ {
+			cells[x+margin+(y+margin)*d.X] = g.cells[x+y*g.dimensions.X]
+		}
+	}
+
+	next := g.successor(cells)
+	next.dimensions = d
+	next.mask = nil
+
+	return next
+}
+
+// canonical returns the rotation or reflection of g with the smallest
+// extended Wechsler encoding, along with that encoding
+func canonical(g *Generation) (*Generation, string) {
+	var (
+		best *Generation
+		code string
+	)
+	for _, t := range g.transforms() {
+		if c := wechsler(t); best == nil || wechslerLess(c, code) {
+			best, code = t, c
+		}
+	}
+
+	return best, code
+}
+
+// wechslerLess orders encodings by length and then lexicographically
+func wechslerLess(a, b string) bool {
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+
+	return a < b
+}
+
+// wechsler returns the extended Wechsler encoding of the board. The board is
+// split into strips five rows high, separated by 'z'. Each column of a strip
+// is a digit whose bits are its cells from the top down, with trailing empty
+// columns omitted and runs of empty columns abbreviated as 'w' (two), 'x'
+// (three) or 'y' followed by a digit (four or more).
+func wechsler(g *Generation) string {
+	var code []byte
+	for top := 0; top < g.dimensions.Y; top += 5 {
+		if top > 0 {
+			code = append(code, 'z')
+		}
+
+		zeros := 0
+		for x := 0; x < g.dimensions.X; x++ {
+			column := 0
+			for bit := 0; bit < 5 && top+bit < g.dimensions.Y; bit++ {
+				if g.cells[x+(top+bit)*g.dimensions.X].Alive() {
+					column |= 1 << uint(bit)
+				}
+			}
+
+			if column == 0 {
+				zeros++
+				continue
+			}
+			code = appendZeros(code, zeros)
+			zeros = 0
+			code = append(code, wechslerDigits[column])
+		}
+	}
+
+	return string(code)
+}
+
+// appendZeros appends the abbreviation for a run of n empty columns
+func appendZeros(code []byte, n int) []byte {
+	for n > 0 {
+		switch {
+		case n == 1:
+			code = append(code, '0')
+			n = 0
+		case n == 2:
+			code = append(code, 'w')
+			n = 0
+		case n == 3:
+			code = append(code, 'x')
+			n = 0
+		default:
+			run := min(n, 4+len(zeroRunDigits)-1)
+			code = append(code, 'y', zeroRunDigits[run-4])
+			n -= run
+		}
+	}
+
+	return code
+}
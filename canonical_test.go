@@ -0,0 +1,143 @@
+package life_test
+
+import (
+	"testing"
+
+	"github.com/enocom/life"
+)
+
+func TestApgcode(t *testing.T) {
+	testCases := map[string]struct {
+		seed *life.Generation
+		want string
+	}{
+		"empty": {
+			seed: newBoard(life.Dimension{X: 3, Y: 3}),
+			want: "xs0_0",
+		},
+		"block": {
+			seed: newBoard(life.Dimension{X: 5, Y: 5},
+				[2]int{3, 3}, [2]int{4, 3}, [2]int{3, 4}, [2]int{4, 4},
+			),
+			want: "xs4_33",
+		},
+		"beehive": {
+			seed: newBoard(life.Dimension{X: 6, Y: 6},
+				[2]int{2, 1}, [2]int{3, 1}, [2]int{1, 2}, [2]int{4, 2}, [2]int{2, 3}, [2]int{3, 3},
+			),
+			want: "xs6_696",
+		},
+		"blinker": {
+			seed: newBoard(life.Dimension{X: 3, Y: 3}, [2]int{0, 1}, [2]int{1, 1}, [2]int{2, 1}),
+			want: "xp2_7",
+		},
+		"glider": {
+			seed: newBoard(life.Dimension{X: 3, Y: 3},
+				[2]int{1, 0}, [2]int{2, 1}, [2]int{0, 2}, [2]int{1, 2}, [2]int{2, 2},
+			),
+			want: "xq4_153",
+		},
+		"pentadecathlon": {
+			seed: newBoard(life.Dimension{X: 10, Y: 1},
+				[2]int{0, 0}, [2]int{1, 0}, [2]int{2, 0}, [2]int{3, 0}, [2]int{4, 0},
+				[2]int{5, 0}, [2]int{6, 0}, [2]int{7, 0}, [2]int{8, 0}, [2]int{9, 0},
+			),
+			want: "xp15_4r4z4r4",
+		},
+		"blocks 40 columns apart": {
+			seed: newBoard(life.Dimension{X: 44, Y: 2},
+				[2]int{0, 0}, [2]int{1, 0}, [2]int{0, 1}, [2]int{1, 1},
+				[2]int{42, 0}, [2]int{43, 0}, [2]int{42, 1}, [2]int{43, 1},
+			),
+			want: "xs8_33yz033",
+		},
+	}
+
+	for description, tc := range testCases {
+		if got := tc.seed.Apgcode(); got != tc.want {
+			t.Errorf("(%s): want %v, got %v", description, tc.want, got)
+		}
+	}
+}
+
+func TestCanonicalForm(t *testing.T) {
+	// two orientations of the same L shape in different positions
+	a := newBoard(life.Dimension{X: 5, Y: 5}, [2]int{1, 1}, [2]int{1, 2}, [2]int{2, 2})
+	b := newBoard(life.Dimension{X: 4, Y: 4}, [2]int{3, 0}, [2]int{2, 0}, [2]int{3, 1})
+
+	ca, cb := a.CanonicalForm(), b.CanonicalForm()
+	if ca.String() != cb.String() {
+		t.Errorf("want: equal canonical forms, got %#v and %#v", ca.String(), cb.String())
+	}
+
+	if d := (life.Dimension{X: 2, Y: 2}); ca.Dimension() != d {
+		t.Errorf("want: %v, got: %v", d, ca.Dimension())
+	}
+
+	empty := newBoard(life.Dimension{X: 3, Y: 3}).CanonicalForm()
+	if d := (life.Dimension{}); empty.Dimension() != d {
+		t.Errorf("want: %v, got: %v", d, empty.Dimension())
+	}
+}
+
+func TestIdentify(t *testing.T) {
+	glider := newBoard(life.Dimension{X: 3, Y: 3},
+		[2]int{1, 0}, [2]int{2, 1}, [2]int{0, 2}, [2]int{1, 2}, [2]int{2, 2},
+	)
+	lib := map[string]*life.Generation{
+		"glider":  glider,
+		"block":   newBoard(life.Dimension{X: 2, Y: 2}, [2]int{0, 0}, [2]int{1, 0}, [2]int{0, 1}, [2]int{1, 1}),
+		"blinker": newBoard(life.Dimension{X: 3, Y: 1}, [2]int{0, 0}, [2]int{1, 0}, [2]int{2, 0}),
+	}
+
+	testCases := map[string]struct {
+		g    *life.Generation
+		want string
+		ok   bool
+	}{
+		"glider reflected elsewhere on a larger board": {
+			g:    newBoard(life.Dimension{X: 8, Y: 8}, [2]int{5, 3}, [2]int{4, 4}, [2]int{4, 5}, [2]int{5, 5}, [2]int{6, 5}),
+			want: "glider",
+			ok:   true,
+		},
+		"vertical blinker": {
+			g:    newBoard(life.Dimension{X: 4, Y: 4}, [2]int{2, 0}, [2]int{2, 1}, [2]int{2, 2}),
+			want: "blinker",
+			ok:   true,
+		},
+		"unknown": {
+			g: newBoard(life.Dimension{X: 4, Y: 4}, [2]int{0, 0}, [2]int{3, 3}),
+		},
+		"empty": {
+			g: newBoard(life.Dimension{X: 4, Y: 4}),
+		},
+	}
+
+	for description, tc := range testCases {
+		got, ok := life.Identify(tc.g, lib)
+		if got != tc.want || ok != tc.ok {
+			t.Errorf("(%s): want: %q (ok = %v), got: %q (ok = %v)", description, tc.want, tc.ok, got, ok)
+		}
+	}
+
+	// duplicate entries resolve to the same name every time
+	lib["a glider too"] = glider.CanonicalForm()
+	for i := 0; i < 10; i++ {
+		if got, _ := life.Identify(glider, lib); got != "a glider too" {
+			t.Fatalf("want: the first name of the matches, got: %q", got)
+		}
+	}
+}
+
+func TestApgcodeMasked(t *testing.T) {
+	d := life.Dimension{X: 8, Y: 8}
+	g := life.NewGeneration(
+		life.WithDimension(d),
+		life.WithCells(newBoard(d, [2]int{6, 5}, [2]int{7, 6}, [2]int{5, 7}, [2]int{6, 7}, [2]int{7, 7}).Cells()),
+		life.WithMask(func(x, y int) bool { return x < 8 && y < 8 }),
+	)
+
+	if got, want := g.Apgcode(), "xq4_153"; got != want {
+		t.Errorf("want: %v, got: %v", want, got)
+	}
+}
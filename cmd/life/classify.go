@@ -0,0 +1,81 @@
+//go:build !windows
+// +build !windows
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/enocom/life"
+)
+
+// exitCodes maps each classification to the status classify exits with. The
+// codes are part of the command's interface, so scripts can rely on them;
+// 2 is left to errors, as the flag package already exits with it.
+var exitCodes = map[life.Classification]int{
+	life.StillLife:  0,
+	life.Unsettled:  1,
+	life.Dead:       3,
+	life.Oscillator: 4,
+}
+
+const classifyUsage = `usage: life classify [-max N] [-rule B/S] PATH
+
+Runs the .cells or .rle pattern at PATH until it settles or -max generations
+have run, in otherwise empty space, and prints what it settled into.
+The exit status tells the outcomes apart:
+
+  0  still life
+  1  unsettled: still changing after -max generations
+  2  error
+  3  dead: no live cells remain
+  4  oscillator
+
+`
+
+// classify runs the pattern named in args to completion, printing its
+// classification to out, and returns the status to exit with
+func classify(args []string, out io.Writer) (int, error) {
+	flags := flag.NewFlagSet("classify", flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), classifyUsage)
+		flags.PrintDefaults()
+	}
+	maxGen := flags.Int("max", 1000, "the most generations to run")
+	ruleFlag := flags.String("rule", "", "the rule in B/S notation, overriding any rule in the pattern file")
+	flags.Parse(args)
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return 2, nil
+	}
+
+	g, err := loadPattern(flags.Arg(0))
+	if err != nil {
+		return 2, err
+	}
+	rule := g.Rule()
+	if *ruleFlag != "" {
+		rule, err = life.ParseRule(*ruleFlag)
+		if err != nil {
+			return 2, fmt.Errorf("invalid -rule %q: want B/S notation, e.g. B3/S23 or B36/S23", *ruleFlag)
+		}
+	}
+
+	cls := life.Classify(g, rule, *maxGen)
+	fmt.Fprintln(out, cls)
+
+	return exitCodes[cls], nil
+}
+
+// exitClassify runs the classify subcommand and exits with its status
+func exitClassify(args []string) {
+	code, err := classify(args, os.Stdout)
+	if err != nil {
+		exit(err)
+	}
+	os.Exit(code)
+}
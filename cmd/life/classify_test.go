@@ -0,0 +1,56 @@
+//go:build !windows
+// +build !windows
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestClassifyExitCodes(t *testing.T) {
+	testCases := map[string]struct {
+		pattern string
+		code    int
+		out     string
+	}{
+		"blinker": {
+			pattern: "x = 3, y = 1, rule = B3/S23\n3o!\n",
+			code:    4,
+			out:     "oscillator\n",
+		},
+		"block": {
+			pattern: "x = 2, y = 2, rule = B3/S23\n2o$2o!\n",
+			code:    0,
+			out:     "still life\n",
+		},
+		"lone cell": {
+			pattern: "x = 1, y = 1, rule = B3/S23\no!\n",
+			code:    3,
+			out:     "dead\n",
+		},
+		"glider": {
+			pattern: "x = 3, y = 3, rule = B3/S23\nbo$2bo$3o!\n",
+			code:    1,
+			out:     "unsettled\n",
+		},
+	}
+
+	for description, tc := range testCases {
+		path := filepath.Join(t.TempDir(), "pattern.rle")
+		if err := os.WriteFile(path, []byte(tc.pattern), 0666); err != nil {
+			t.Fatal(err)
+		}
+
+		var out bytes.Buffer
+		code, err := classify([]string{"-max", "100", path}, &out)
+		if err != nil {
+			t.Fatalf("(%s): want: no error, got: %v", description, err)
+		}
+		if code != tc.code || out.String() != tc.out {
+			t.Errorf("(%s): want: %q and status %d, got: %q and status %d", description, tc.out, tc.code, out.String(), code)
+		}
+	}
+}
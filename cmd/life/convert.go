@@ -0,0 +1,87 @@
+//go:build !windows
+// +build !windows
+
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/enocom/life"
+)
+
+// convert reads the pattern named by args[0] and writes it to the file named
+// by args[1], choosing each format by its extension. The pattern's name,
+// author, comments and rule are carried across. An existing output file is
+// left alone, with an error matching fs.ErrExist, unless -force is given.
+func convert(args []string) error {
+	flags := flag.NewFlagSet("convert", flag.ExitOnError)
+	force := flags.Bool("force", false, "overwrite the output file if it exists")
+	flags.Parse(args)
+	args = flags.Args()
+	if len(args) != 2 {
+		return errors.New("usage: life convert [-force] IN OUT")
+	}
+
+	g, err := loadPattern(args[0])
+	if err != nil {
+		return err
+	}
+
+	write := g.WritePlaintext
+	switch ext := strings.ToLower(filepath.Ext(args[1])); ext {
+	case ".rle":
+		write = g.WriteRLE
+	case ".cells":
+	default:
+		return fmt.Errorf("unknown pattern format %q: want .rle or .cells", ext)
+	}
+
+	f, err := create(args[1], *force)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// loadPattern reads the pattern at path, as RLE if it has an .rle extension
+// and as plaintext otherwise
+func loadPattern(path string) (*life.Generation, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	if strings.EqualFold(filepath.Ext(path), ".rle") {
+		return life.LoadRLE(f)
+	}
+
+	return life.LoadPlaintext(f)
+}
+
+// create creates the file at path for writing, refusing to truncate an
+// existing file unless overwrite is set. The error for an existing file
+// matches fs.ErrExist, so it can be told apart from other failures.
+func create(path string, overwrite bool) (*os.File, error) {
+	if overwrite {
+		return os.Create(path)
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0666)
+	if errors.Is(err, fs.ErrExist) {
+		return nil, fmt.Errorf("%s already exists; use -force to overwrite it: %w", path, err)
+	}
+
+	return f, err
+}
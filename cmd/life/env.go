@@ -0,0 +1,55 @@
+//go:build !windows
+// +build !windows
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/enocom/life"
+)
+
+// defaults holds the default values of the flags which may be set from the
+// environment
+type defaults struct {
+	size    int
+	rate    time.Duration
+	rule    string
+	ruleSet bool // whether LIFE_RULE gave the rule
+}
+
+// envDefaults reads the defaults for -size, -rate and -rule from the
+// LIFE_SIZE, LIFE_RATE and LIFE_RULE environment variables, for use where
+// passing flags is awkward. Flags given on the command line still win. An
+// invalid value is an error rather than being ignored.
+func envDefaults() (defaults, error) {
+	d := defaults{size: 10, rate: time.Second, rule: "B3/S23"}
+
+	if v := os.Getenv("LIFE_SIZE"); v != "" {
+		size, err := strconv.Atoi(v)
+		if err != nil || size < 1 {
+			return d, fmt.Errorf("invalid LIFE_SIZE %q: want a positive integer", v)
+		}
+		d.size = size
+	}
+
+	if v := os.Getenv("LIFE_RATE"); v != "" {
+		rate, err := time.ParseDuration(v)
+		if err != nil {
+			return d, fmt.Errorf("invalid LIFE_RATE %q: want a duration, e.g. 500ms", v)
+		}
+		d.rate = rate
+	}
+
+	if v := os.Getenv("LIFE_RULE"); v != "" {
+		if _, err := life.ParseRule(v); err != nil {
+			return d, fmt.Errorf("invalid LIFE_RULE %q: want B/S notation, e.g. B3/S23 or B36/S23", v)
+		}
+		d.rule, d.ruleSet = v, true
+	}
+
+	return d, nil
+}
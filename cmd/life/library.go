@@ -0,0 +1,50 @@
+//go:build !windows
+// +build !windows
+
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/enocom/life"
+)
+
+// choosePattern lists the patterns of a library as a numbered menu on out and
+// reads the number of the one to run from in, asking again until a pattern
+// which loaded is chosen
+func choosePattern(patterns []life.NamedPattern, in io.Reader, out io.Writer) (life.NamedPattern, error) {
+	if len(patterns) == 0 {
+		return life.NamedPattern{}, errors.New("no .rle or .cells patterns found in -library")
+	}
+
+	for i, p := range patterns {
+		if p.Err != nil {
+			fmt.Fprintf(out, "%3d) %s (error: %v)\n", i+1, p.Name, p.Err)
+			continue
+		}
+		fmt.Fprintf(out, "%3d) %s\n", i+1, p.Name)
+	}
+
+	s := bufio.NewScanner(in)
+	for {
+		fmt.Fprintf(out, "pattern [1-%d]: ", len(patterns))
+		if !s.Scan() {
+			return life.NamedPattern{}, errors.New("no pattern chosen")
+		}
+
+		n, err := strconv.Atoi(strings.TrimSpace(s.Text()))
+		switch {
+		case err != nil || n < 1 || n > len(patterns):
+			fmt.Fprintf(out, "want a number from 1 to %d\n", len(patterns))
+		case patterns[n-1].Err != nil:
+			fmt.Fprintf(out, "%s could not be loaded\n", patterns[n-1].Name)
+		default:
+			return patterns[n-1], nil
+		}
+	}
+}
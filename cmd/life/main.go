@@ -7,26 +7,224 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/enocom/life"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "convert" {
+		if err := convert(os.Args[2:]); err != nil {
+			exit(err)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "classify" {
+		exitClassify(os.Args[2:])
+	}
+
+	env, err := envDefaults()
+	if err != nil {
+		exit(err)
+	}
+
 	var c config
-	flag.IntVar(&c.size, "size", 10, "the size of the game's dimensions")
-	flag.DurationVar(&c.rate, "rate", time.Second, "the rate of generation refresh")
+	flag.IntVar(&c.size, "size", env.size, "the size of the game's dimensions; LIFE_SIZE sets the default")
+	flag.DurationVar(&c.rate, "rate", env.rate, "the rate of generation refresh; LIFE_RATE sets the default")
+	flag.BoolVar(&c.fit, "fit", false, "size the board to fit the terminal, ignoring -size")
+	flag.StringVar(&c.seed, "seed", "random", "the initial board: random, or file:PATH to a .cells or .rle pattern")
+	flag.StringVar(&c.library, "library", "", "choose the initial board from a menu of the patterns in a directory")
+	flag.StringVar(&c.seedString, "seed-string", "", "seed the random board from a word, so the same word gives the same board")
+	flag.StringVar(&c.text, "text", "", "seed the board with text, centred, to watch it dissolve")
+	flag.BoolVar(&c.watch, "watch", false, "restart the game whenever the -seed file changes")
+	flag.StringVar(&c.neighborhood, "neighborhood", "moore", "the cells counted as neighbors: "+neighborhoodNames())
+	flag.BoolVar(&c.axes, "axes", false, "label the rows and columns of the board")
+	flag.BoolVar(&c.wrap, "wrap", false, "join the opposite edges of the board, marking them with a border")
+	flag.BoolVar(&c.plain, "plain", false, "print each frame after the last without escape codes, for logs")
+	flag.StringVar(&c.rule, "rule", env.rule, "the rule in B/S notation, overriding any rule in the -seed file; LIFE_RULE sets the default")
+	flag.StringVar(&c.control, "control", "", "read pause, resume, step, reset and speed F commands from a named pipe, created if missing")
+	flag.StringVar(&c.trace, "trace", "", "write the time taken to compute and draw each generation to a CSV file")
 	flag.Parse()
 
+	path, err := seedPath(c.seed)
+	if err != nil {
+		exit(err)
+	}
+	if c.seedString != "" && (path != "" || c.library != "") {
+		exit(fmt.Errorf("-seed-string cannot be combined with -seed file:PATH or -library"))
+	}
+	if c.text != "" && (path != "" || c.library != "" || c.seedString != "") {
+		exit(fmt.Errorf("-text cannot be combined with -seed file:PATH, -library or -seed-string"))
+	}
+	if c.library != "" {
+		if path != "" {
+			exit(fmt.Errorf("-library cannot be combined with -seed file:PATH"))
+		}
+		patterns, err := life.LoadLibrary(c.library)
+		if err != nil {
+			exit(err)
+		}
+		chosen, err := choosePattern(patterns, os.Stdin, os.Stdout)
+		if err != nil {
+			exit(err)
+		}
+		path = chosen.Path
+	}
+	if c.watch && path == "" {
+		exit(fmt.Errorf("-watch requires -seed file:PATH or -library"))
+	}
+	n, ok := neighborhoods[c.neighborhood]
+	if !ok {
+		exit(fmt.Errorf("invalid -neighborhood %q: want one of %s", c.neighborhood, neighborhoodNames()))
+	}
+	rule, err := life.ParseRule(c.rule)
+	if err != nil {
+		exit(fmt.Errorf("invalid -rule %q: want B/S notation, e.g. B3/S23 or B36/S23", c.rule))
+	}
+	s := seeder{neighborhood: n, wrap: c.wrap}
+	if isFlagSet("rule") || env.ruleSet {
+		s.rule = &rule
+	}
+
 	go listenForInterrupt()
 
-	g := life.NewGame(
+	genOpts := []life.Option{life.WithNeighborhood(n), life.WithRule(rule)}
+	if c.seedString != "" {
+		genOpts = append(genOpts, life.WithSeedString(c.seedString))
+	}
+	if c.text != "" {
+		genOpts = append(genOpts, life.WithTextSeed(c.text))
+	}
+	var renderOpts []life.RenderOption
+	if c.axes {
+		renderOpts = append(renderOpts, life.WithAxes())
+	}
+	if c.wrap {
+		genOpts = append(genOpts, life.WithWrap())
+		renderOpts = append(renderOpts, life.WithWrapIndicator())
+	}
+
+	opts := []life.GameOption{
 		life.WithBoardSize(c.size),
 		life.WithGenerationRate(c.rate),
-	)
+		life.WithGenerationOptions(genOpts...),
+		life.WithRenderOptions(renderOpts...),
+		life.WithSignalControl(),
+	}
+	if c.fit {
+		opts = append(opts, life.WithFitTerminal())
+	}
+	if c.plain {
+		opts = append(opts, life.WithUI(life.NewPlainUI(os.Stdout)))
+	}
+	if c.control != "" {
+		opts = append(opts, life.WithControlPipe(c.control))
+	}
+	if c.trace != "" {
+		opts = append(opts, life.WithTraceFile(c.trace))
+	}
+
+	if c.watch {
+		watch(path, s, opts)
+		return
+	}
+
+	if path != "" {
+		seed, err := s.load(path)
+		if err != nil {
+			exit(err)
+		}
+		opts = append(opts, life.WithGeneration(seed))
+	}
+
+	g := life.NewGame(opts...)
 	g.Start()
 }
 
+// seedPath returns the pattern file named by a -seed value, or an empty path
+// for a random board
+func seedPath(seed string) (string, error) {
+	if seed == "random" {
+		return "", nil
+	}
+
+	path := strings.TrimPrefix(seed, "file:")
+	if path == seed || path == "" {
+		return "", fmt.Errorf("invalid -seed %q: want random or file:PATH", seed)
+	}
+
+	return path, nil
+}
+
+// seeder loads the pattern a game starts from, configured by the flags
+type seeder struct {
+	neighborhood life.Neighborhood
+	rule         *life.Rule // overrides the pattern's own rule when set
+	wrap         bool
+}
+
+// load reads the pattern at path
+func (s seeder) load(path string) (*life.Generation, error) {
+	g, err := loadPattern(path)
+	if err != nil {
+		return nil, err
+	}
+
+	rule := g.Rule()
+	if s.rule != nil {
+		rule = *s.rule
+	}
+
+	opts := []life.Option{
+		life.WithDimension(g.Dimension()),
+		life.WithNeighborhood(s.neighborhood),
+		life.WithRule(rule),
+		life.WithMeta(g.Meta()),
+		life.WithCells(g.Cells()),
+	}
+	if s.wrap {
+		opts = append(opts, life.WithWrap())
+	}
+
+	return life.NewGeneration(opts...), nil
+}
+
+// isFlagSet reports whether the named flag was passed on the command line
+func isFlagSet(name string) bool {
+	set := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+
+	return set
+}
+
+var neighborhoods = map[string]life.Neighborhood{
+	"moore":      life.Moore,
+	"vonneumann": life.VonNeumann,
+	"hexagonal":  life.Hexagonal,
+}
+
+// neighborhoodNames lists the accepted -neighborhood values
+func neighborhoodNames() string {
+	var names []string
+	for name := range neighborhoods {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return strings.Join(names, ", ")
+}
+
+func exit(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(2)
+}
+
 func listenForInterrupt() {
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt)
@@ -36,6 +234,19 @@ func listenForInterrupt() {
 }
 
 type config struct {
-	size int
-	rate time.Duration
+	size         int
+	rate         time.Duration
+	fit          bool
+	seed         string
+	seedString   string
+	text         string
+	library      string
+	watch        bool
+	neighborhood string
+	axes         bool
+	wrap         bool
+	plain        bool
+	rule         string
+	control      string
+	trace        string
 }
@@ -0,0 +1,54 @@
+//go:build !windows
+// +build !windows
+
+package main
+
+import (
+	"os"
+	"time"
+
+	"github.com/enocom/life"
+)
+
+// watchInterval is how often the seed file is checked for changes
+const watchInterval = 500 * time.Millisecond
+
+// watch runs a game seeded from the pattern at path, restarting it from the
+// new pattern whenever the file is modified. A file which cannot be read or
+// parsed, e.g. because it is partway through being saved, is retried on the
+// next check.
+func watch(path string, s seeder, opts []life.GameOption) {
+	var (
+		modTime time.Time
+		game    *life.Game
+		done    chan struct{}
+	)
+
+	t := time.NewTicker(watchInterval)
+	defer t.Stop()
+
+	for ; ; <-t.C {
+		info, err := os.Stat(path)
+		if err != nil || info.ModTime().Equal(modTime) {
+			continue
+		}
+
+		seed, err := s.load(path)
+		if err != nil {
+			continue
+		}
+		modTime = info.ModTime()
+
+		if game != nil {
+			game.Stop()
+			<-done
+		}
+
+		game = life.NewGame(append(opts, life.WithGeneration(seed))...)
+		done = make(chan struct{})
+		go func(g *life.Game, done chan struct{}) {
+			g.Run()
+			close(done)
+		}(game, done)
+	}
+}
@@ -0,0 +1,95 @@
+package life
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Encode returns the board as a compact code suitable for a URL or any other
+// single token of text. Only the dimensions and the state of each cell are
+// kept; Decode reverses it.
+//
+// The code is the unpadded URL-safe base64 encoding (RFC 4648 section 5) of a
+// gzip stream. The stream holds the width and then the height of the board,
+// each an unsigned varint as written by encoding/binary, followed by one bit
+// per cell, row by row from the top left. The first cell of each byte is its
+// most significant bit, a set bit is a live cell, and the final byte is padded
+// with zeros.
+func (g *Generation) Encode() string {
+	var raw bytes.Buffer
+	zw := gzip.NewWriter(&raw)
+
+	header := binary.AppendUvarint(nil, uint64(g.dimensions.X))
+	header = binary.AppendUvarint(header, uint64(g.dimensions.Y))
+	// writing to a bytes.Buffer cannot fail
+	_, _ = zw.Write(header)
+	_, _ = zw.Write(g.packBits())
+	_ = zw.Close()
+
+	return base64.RawURLEncoding.EncodeToString(raw.Bytes())
+}
+
+// Decode builds a generation from a code produced by Encode. Neither side of
+// the board may exceed MaxPatternDimension.
+func Decode(s string) (*Generation, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("life: invalid board code: %w", err)
+	}
+
+	zr, err := gzip.NewReader(bytes.NewReader(raw))
+	if err != nil {
+		return nil, fmt.Errorf("life: invalid board code: %w", err)
+	}
+	r := bufio.NewReader(zr)
+
+	var d Dimension
+	for _, n := range []*int{&d.X, &d.Y} {
+		v, err := binary.ReadUvarint(r)
+		if err != nil {
+			return nil, fmt.Errorf("life: invalid board code: %w", err)
+		}
+		if v > MaxPatternDimension {
+			return nil, fmt.Errorf("life: board code dimension %d exceeds %d", v, MaxPatternDimension)
+		}
+		*n = int(v)
+	}
+
+	bits := make([]byte, (d.X*d.Y+7)/8)
+	if _, err := io.ReadFull(r, bits); err != nil {
+		return nil, fmt.Errorf("life: invalid board code: %w", err)
+	}
+	// reaching the end of the stream also verifies its checksum
+	if _, err := r.ReadByte(); err != io.EOF {
+		if err == nil {
+			err = errors.New("trailing data")
+		}
+		return nil, fmt.Errorf("life: invalid board code: %w", err)
+	}
+
+	cells := make([]Cell, d.X*d.Y)
+	for i := range cells {
+		cells[i] = Cell{alive: bits[i/8]&(0x80>>(i%8)) != 0}
+	}
+
+	return NewGeneration(WithDimension(d), WithCells(cells)), nil
+}
+
+// packBits returns one bit per cell, the first cell in the most significant
+// bit of the first byte, where a set bit is a live cell
+func (g *Generation) packBits() []byte {
+	bits := make([]byte, (len(g.cells)+7)/8)
+	for i, c := range g.cells {
+		if c.Alive() {
+			bits[i/8] |= 0x80 >> (i % 8)
+		}
+	}
+
+	return bits
+}
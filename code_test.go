@@ -0,0 +1,45 @@
+package life_test
+
+import (
+	"testing"
+
+	"github.com/enocom/life"
+)
+
+func TestEncodeRoundTrip(t *testing.T) {
+	testCases := map[string]*life.Generation{
+		"glider": newBoard(life.Dimension{X: 3, Y: 3},
+			[2]int{1, 0}, [2]int{2, 1}, [2]int{0, 2}, [2]int{1, 2}, [2]int{2, 2}),
+		"random": life.NewGeneration(life.WithDimension(life.Dimension{X: 37, Y: 11}), life.WithRandomSeed(7)),
+		"empty":  newBoard(life.Dimension{}),
+	}
+
+	for description, g := range testCases {
+		code := g.Encode()
+		got, err := life.Decode(code)
+		if err != nil {
+			t.Fatalf("(%s): want: no error, got: %v", description, err)
+		}
+		if got.Dimension() != g.Dimension() || !equal(got.Cells(), g.Cells()) {
+			t.Errorf("(%s): want: %v, got: %v", description, g, got)
+		}
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	glider := newBoard(life.Dimension{X: 3, Y: 3}, [2]int{1, 0}).Encode()
+
+	testCases := map[string]string{
+		"empty":         "",
+		"not base64":    "!!!",
+		"not gzip":      "aGVsbG8",
+		"truncated":     glider[:len(glider)-4],
+		"padded base64": glider + "=",
+	}
+
+	for description, code := range testCases {
+		if _, err := life.Decode(code); err == nil {
+			t.Errorf("(%s): want an error, got nil", description)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package life
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// comparisonGap separates the boards drawn by RunComparison
+const comparisonGap = "   "
+
+// RunComparison plays several games side by side, advancing each by one
+// generation every rate and drawing all of their boards to w in a row, each
+// under a label naming its position, e.g. "game 1". Boards of different sizes
+// are aligned along their top edges. Each game keeps its own generation
+// options, render options and limits, and stops advancing once it is done;
+// RunComparison returns when every game is done. The games' own UIs,
+// generation rates and terminal fitting are not used.
+func RunComparison(w io.Writer, games []*Game, rate time.Duration) error {
+	if len(games) == 0 {
+		return errors.New("life: no games to compare")
+	}
+
+	start := time.Now()
+	ui := NewTerminalUI(w)
+	boards := make([]*Generation, len(games))
+	for i, g := range games {
+		boards[i] = g.initial()
+		g.setCurrent(0, boards[i])
+	}
+
+	frames := newSchedule(rate)
+	for n := 0; ; n++ {
+		ui.ClearScreen()
+		ui.Write(sideBySide(games, boards))
+
+		running := false
+		for i, g := range games {
+			if g.done(n, time.Since(start), boards[i]) {
+				continue
+			}
+			running = true
+		}
+		if !running {
+			return nil
+		}
+
+		<-frames.wait(n + 1)
+		for i, g := range games {
+			if g.StopReason() != Running {
+				continue
+			}
+
+			next, err := NextErr(boards[i])
+			if err != nil {
+				return fmt.Errorf("life: game %d: %w", i+1, err)
+			}
+			boards[i] = next
+			g.setCurrent(n+1, next)
+		}
+	}
+}
+
+// sideBySide draws each game's board with that game's render options and
+// joins them into rows, padding each to the width of its widest line
+func sideBySide(games []*Game, boards []*Generation) string {
+	var (
+		columns [][]string
+		widths  []int
+		height  int
+	)
+	for i, g := range games {
+		lines := append([]string{fmt.Sprintf("game %d", i+1)},
+			strings.Split(strings.TrimSuffix(boards[i].Render(g.renderOpts...), "\n"), "\n")...)
+
+		width := 0
+		for _, l := range lines {
+			width = max(width, utf8.RuneCountInString(l))
+		}
+		columns = append(columns, lines)
+		widths = append(widths, width)
+		height = max(height, len(lines))
+	}
+
+	var b strings.Builder
+	for row := 0; row < height; row++ {
+		var line strings.Builder
+		for i, lines := range columns {
+			if i > 0 {
+				line.WriteString(comparisonGap)
+			}
+			cell := ""
+			if row < len(lines) {
+				cell = lines[row]
+			}
+			line.WriteString(cell + strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell)))
+		}
+		b.WriteString(strings.TrimRight(line.String(), " "))
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
@@ -0,0 +1,58 @@
+package life_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/enocom/life"
+)
+
+func TestRunComparison(t *testing.T) {
+	blinker := newBoard(life.Dimension{X: 3, Y: 3}, [2]int{0, 1}, [2]int{1, 1}, [2]int{2, 1})
+	lonely := newBoard(life.Dimension{X: 2, Y: 1}, [2]int{0, 0})
+
+	games := []*life.Game{
+		life.NewGame(
+			life.WithGeneration(blinker),
+			life.WithMaxGenerations(1),
+			life.WithRenderOptions(life.WithDeadGlyph(".")),
+		),
+		life.NewGame(
+			life.WithGeneration(lonely),
+			life.WithMaxGenerations(1),
+			life.WithRenderOptions(life.WithDeadGlyph(".")),
+		),
+	}
+
+	var buf bytes.Buffer
+	if err := life.RunComparison(&buf, games, 0); err != nil {
+		t.Fatalf("want: no error, got: %v", err)
+	}
+
+	clear := "\033[H\033[2J"
+	want := clear +
+		"game 1   game 2\n" +
+		". . .    o .\n" +
+		"o o o\n" +
+		". . .\n" +
+		clear +
+		"game 1   game 2\n" +
+		". o .    . .\n" +
+		". o .\n" +
+		". o .\n"
+	if got := buf.String(); got != want {
+		t.Errorf("want: %#v, got: %#v", want, got)
+	}
+
+	for i, g := range games {
+		if r := g.StopReason(); r != life.MaxGenerationsReached {
+			t.Errorf("game %d: want: %v, got: %v", i+1, life.MaxGenerationsReached, r)
+		}
+	}
+}
+
+func TestRunComparisonNoGames(t *testing.T) {
+	if err := life.RunComparison(&bytes.Buffer{}, nil, 0); err == nil {
+		t.Error("want: an error without games to compare")
+	}
+}
@@ -0,0 +1,53 @@
+package life
+
+// Components splits the live cells into connected clusters, treating cells as
+// connected when they touch along an edge or at a corner, and returns each
+// cluster normalized as its own generation. Clusters are ordered by their
+// first cell in index order. Clusters are not joined across the edges of a
+// board which wraps. An empty board has no components.
+func (g *Generation) Components() []*Generation {
+	components := []*Generation{}
+	for _, c := range g.clusters() {
+		components = append(components, c.Normalize())
+	}
+
+	return components
+}
+
+// clusters returns a copy of the board for each connected cluster of live
+// cells, holding only that cluster's cells in their original positions
+func (g *Generation) clusters() []*Generation {
+	d := g.dimensions
+	seen := make([]bool, len(g.cells))
+
+	var clusters []*Generation
+	for start, c := range g.cells {
+		if !c.Alive() || seen[start] {
+			continue
+		}
+
+		cells := make([]Cell, len(g.cells))
+		seen[start] = true
+		stack := []int{start}
+		for len(stack) > 0 {
+			idx := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			cells[idx] = g.cells[idx]
+
+			x, y := idx%d.X, idx/d.X
+			for ny := max(y-1, 0); ny <= min(y+1, d.Y-1); ny++ {
+				for nx := max(x-1, 0); nx <= min(x+1, d.X-1); nx++ {
+					n := nx + ny*d.X
+					if !seen[n] && g.cells[n].Alive() {
+						seen[n] = true
+						stack = append(stack, n)
+					}
+				}
+			}
+		}
+
+		clusters = append(clusters, g.successor(cells))
+	}
+
+	return clusters
+}
@@ -0,0 +1,42 @@
+package life_test
+
+import (
+	"testing"
+
+	"github.com/enocom/life"
+)
+
+func TestComponents(t *testing.T) {
+	// . o . . . . .
+	// . . o . . o o
+	// o o o . . o o
+	// . . . . . . .
+	// o . . . . . .
+	g := newBoard(life.Dimension{X: 7, Y: 5},
+		[2]int{1, 0}, [2]int{2, 1}, [2]int{0, 2}, [2]int{1, 2}, [2]int{2, 2},
+		[2]int{5, 1}, [2]int{6, 1}, [2]int{5, 2}, [2]int{6, 2},
+		[2]int{0, 4},
+	)
+
+	got := g.Components()
+	want := []*life.Generation{
+		newBoard(life.Dimension{X: 3, Y: 3}, [2]int{1, 0}, [2]int{2, 1}, [2]int{0, 2}, [2]int{1, 2}, [2]int{2, 2}),
+		newBoard(life.Dimension{X: 2, Y: 2}, [2]int{0, 0}, [2]int{1, 0}, [2]int{0, 1}, [2]int{1, 1}),
+		newBoard(life.Dimension{X: 1, Y: 1}, [2]int{0, 0}),
+	}
+	if len(got) != len(want) {
+		t.Fatalf("want: %v components, got: %v", len(want), len(got))
+	}
+	for i := range want {
+		if got[i].Dimension() != want[i].Dimension() || !equal(got[i].Cells(), want[i].Cells()) {
+			t.Errorf("component %d: want: %#v, got: %#v", i, want[i].String(), got[i].String())
+		}
+	}
+}
+
+func TestComponentsEmpty(t *testing.T) {
+	got := newBoard(life.Dimension{X: 3, Y: 3}).Components()
+	if got == nil || len(got) != 0 {
+		t.Errorf("want: an empty slice, got: %#v", got)
+	}
+}
@@ -0,0 +1,52 @@
+package life
+
+// RunUntilContains evolves g under rule for up to maxGen generations and
+// returns the first generation, counting g itself as generation zero, in which
+// the pattern of live cells in target appears anywhere on the board. The
+// target is normalized first, so it may come from a board of any size; it only
+// matches in the same orientation, with the cells of its bounding box alive
+// and dead exactly as in the pattern. An empty target is found at generation
+// zero. The second return value is false if the target did not appear within
+// maxGen generations.
+func RunUntilContains(g *Generation, target *Generation, rule Rule, maxGen int) (int, bool) {
+	pattern := target.Normalize()
+	board := g.successor(g.cells)
+	board.rule = rule
+
+	for n := 0; n <= maxGen; n++ {
+		if board.contains(pattern) {
+			return n, true
+		}
+		board = Next(board)
+	}
+
+	return 0, false
+}
+
+// contains reports whether pattern matches the cells of the generation at any
+// position where it fits on the board
+func (g *Generation) contains(pattern *Generation) bool {
+	p, d := pattern.dimensions, g.dimensions
+	for y := 0; y+p.Y <= d.Y; y++ {
+		for x := 0; x+p.X <= d.X; x++ {
+			if g.matchesAt(pattern, x, y) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// matchesAt reports whether pattern's top left corner at (x, y) lines up with
+// cells of the same liveness
+func (g *Generation) matchesAt(pattern *Generation, x, y int) bool {
+	p := pattern.dimensions
+	for i, c := range pattern.cells {
+		if g.cells[x+i%p.X+(y+i/p.X)*g.dimensions.X].Alive() != c.Alive() {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,35 @@
+package life_test
+
+import (
+	"testing"
+
+	"github.com/enocom/life"
+)
+
+func TestRunUntilContains(t *testing.T) {
+	d := life.Dimension{X: 6, Y: 6}
+	// three cells which grow into a block after one generation
+	seed := newBoard(d, [2]int{1, 1}, [2]int{2, 1}, [2]int{1, 2})
+	block := newBoard(life.Dimension{X: 4, Y: 4}, [2]int{2, 2}, [2]int{3, 2}, [2]int{2, 3}, [2]int{3, 3})
+	blinker := newBoard(life.Dimension{X: 3, Y: 1}, [2]int{0, 0}, [2]int{1, 0}, [2]int{2, 0})
+
+	testCases := map[string]struct {
+		target *life.Generation
+		maxGen int
+		want   int
+		ok     bool
+	}{
+		"already there":    {target: seed, maxGen: 3, want: 0, ok: true},
+		"appears later":    {target: block, maxGen: 3, want: 1, ok: true},
+		"appears too late": {target: block, maxGen: 0},
+		"never appears":    {target: blinker, maxGen: 3},
+		"empty target":     {target: newBoard(d), maxGen: 3, want: 0, ok: true},
+	}
+
+	for description, tc := range testCases {
+		got, ok := life.RunUntilContains(seed, tc.target, life.Conway, tc.maxGen)
+		if got != tc.want || ok != tc.ok {
+			t.Errorf("(%s): want: %v (ok = %v), got: %v (ok = %v)", description, tc.want, tc.ok, got, ok)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package life
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WithControlPipe reads commands for the game from the named pipe at path,
+// one per line, so another process can drive the game while it runs:
+//
+//	pause       pause the game, as Pause does
+//	resume      resume the game, as Resume does
+//	step        advance a paused game by one generation, as Step does
+//	reset       return to the first generation, as Reset does
+//	speed F     play F times faster than the generation rate, as SetSpeed does
+//
+// The pipe is created if it does not exist, and removed again when the game
+// ends. Writers may come and go: the game keeps listening after a writer
+// closes its end, so for example `echo pause > path` may be run any number of
+// times. Commands which are not understood are reported to the logger set by
+// WithLogger and ignored, as is a file at path which is not a named pipe, in
+// which case the game runs without one.
+func WithControlPipe(path string) GameOption {
+	return func(g *Game) {
+		g.controlPipe = path
+	}
+}
+
+// listenForControl applies the commands read from the control pipe until the
+// returned function is called
+func (g *Game) listenForControl() func() {
+	created := true
+	if err := makeFIFO(g.controlPipe); os.IsExist(err) {
+		created = false
+		// anything else at the path, such as a regular file, would be read
+		// as commands
+		info, err := os.Stat(g.controlPipe)
+		if err != nil {
+			g.logf("life: control pipe: %v", err)
+			return func() {}
+		}
+		if info.Mode()&os.ModeNamedPipe == 0 {
+			g.logf("life: control pipe: %s exists and is not a named pipe", g.controlPipe)
+			return func() {}
+		}
+	} else if err != nil {
+		g.logf("life: control pipe: %v", err)
+		return func() {}
+	}
+	// remove removes the pipe again if the game made it
+	remove := func() {
+		if created {
+			if err := os.Remove(g.controlPipe); err != nil {
+				g.logf("life: control pipe: %v", err)
+			}
+		}
+	}
+	// opening for writing as well as reading means the pipe is never left
+	// without a writer, so a writer closing its end is not seen as the end of
+	// the commands, and opening does not block until a writer arrives
+	f, err := os.OpenFile(g.controlPipe, os.O_RDWR, 0)
+	if err != nil {
+		g.logf("life: control pipe: %v", err)
+		remove()
+		return func() {}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		lines := bufio.NewScanner(f)
+		for lines.Scan() {
+			if err := g.control(lines.Text()); err != nil {
+				g.logf("life: control pipe: %v", err)
+			}
+		}
+	}()
+
+	return func() {
+		// closing the pipe ends the read in progress
+		f.Close()
+		<-done
+		remove()
+	}
+}
+
+// control applies a single command read from the control pipe
+func (g *Game) control(command string) error {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return nil
+	}
+
+	switch name, args := fields[0], fields[1:]; {
+	case name == "pause" && len(args) == 0:
+		g.Pause()
+	case name == "resume" && len(args) == 0:
+		g.Resume()
+	case name == "step" && len(args) == 0:
+		g.Step()
+	case name == "reset" && len(args) == 0:
+		g.Reset()
+	case name == "speed" && len(args) == 1:
+		factor, err := strconv.ParseFloat(args[0], 64)
+		if err != nil || factor <= 0 {
+			return fmt.Errorf("invalid speed %q: want a positive number", args[0])
+		}
+		g.SetSpeed(factor)
+	default:
+		return fmt.Errorf("unknown command %q", command)
+	}
+
+	return nil
+}
+
+// Step advances a paused game by one generation, leaving it paused. Calls
+// made before the step is taken add up, so the game advances once for each.
+// Stepping a game which is not paused has no effect.
+func (g *Game) Step() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.resumed == nil {
+		return
+	}
+	g.steps++
+	// wake the game, which finds the step and keeps waiting on the new
+	// channel once it is taken
+	close(g.resumed)
+	g.resumed = make(chan struct{})
+}
+
+// Reset returns the game to the generation it started from, drawing it. The
+// game carries on from there, counting generations from zero again. Resetting
+// a game which has not started has no effect.
+func (g *Game) Reset() {
+	g.mu.Lock()
+	first := g.first
+	if first == nil {
+		g.mu.Unlock()
+		return
+	}
+	g.history = nil
+	g.current, g.currentN = first, 0
+	g.rewound = &snapshot{n: 0, gen: first}
+	g.publish(0, first)
+	g.mu.Unlock()
+
+	g.render(0, first)
+}
+
+// SetSpeed plays the game factor times faster than its generation rate, or
+// slower for a factor below one, from the next generation on. Factors which
+// are not positive are ignored, as is the speed of a game without a
+// generation rate, which already runs as fast as it can.
+func (g *Game) SetSpeed(factor float64) {
+	if factor <= 0 {
+		return
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.speed = factor
+}
+
+// speedChange returns the generation rate set by SetSpeed, if it has been
+// called since the last call
+func (g *Game) speedChange() (time.Duration, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	factor := g.speed
+	g.speed = 0
+	if factor == 0 {
+		return 0, false
+	}
+
+	return time.Duration(float64(g.rate) / factor), true
+}
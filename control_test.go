@@ -0,0 +1,108 @@
+//go:build linux || darwin || dragonfly || freebsd || netbsd || openbsd
+
+package life_test
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/enocom/life"
+)
+
+// send writes commands to the control pipe at path as a writer of its own,
+// closing its end afterwards
+func send(t *testing.T, path, commands string) {
+	t.Helper()
+
+	f, err := os.OpenFile(path, os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	if _, err := f.WriteString(commands); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestControlPipe(t *testing.T) {
+	glider := newBoard(life.Dimension{X: 8, Y: 8},
+		[2]int{1, 0}, [2]int{2, 1}, [2]int{0, 2}, [2]int{1, 2}, [2]int{2, 2},
+	)
+	gens := []*life.Generation{glider}
+	for i := 0; i < 3; i++ {
+		gens = append(gens, life.Next(gens[i]))
+	}
+
+	path := filepath.Join(t.TempDir(), "control")
+	ui := &pausingUI{after: 1}
+	g := life.NewGame(
+		life.WithUI(ui),
+		life.WithGenerationRate(0),
+		life.WithMaxGenerations(3),
+		life.WithGeneration(glider),
+		life.WithControlPipe(path),
+	)
+	ui.pause = g.Pause
+
+	done := make(chan int)
+	go func() { done <- g.Run() }()
+	waitFor(t, func() bool { return ui.count() == 1 })
+
+	send(t, path, "step\nstep\n")
+	waitFor(t, func() bool { return ui.count() == 3 })
+
+	// a new writer after the first has gone
+	send(t, path, "reset\nstep\n")
+	waitFor(t, func() bool { return ui.count() == 5 })
+
+	send(t, path, "speed 2\nresume\n")
+	if n := <-done; n != 3 {
+		t.Errorf("want: 3 generations, got: %v", n)
+	}
+
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("want: the pipe the game made to be removed, got: %v", err)
+	}
+
+	want := []*life.Generation{gens[0], gens[1], gens[2], gens[0], gens[1], gens[2], gens[3]}
+	if len(ui.frames) != len(want) {
+		t.Fatalf("want: %v frames, got: %v", len(want), len(ui.frames))
+	}
+	for i, w := range want {
+		if ui.frames[i] != w.String() {
+			t.Errorf("frame %d: want: %#v, got: %#v", i, w.String(), ui.frames[i])
+		}
+	}
+}
+
+func TestControlPipeNotAPipe(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "control")
+	if err := os.WriteFile(path, []byte("pause\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	var logs bytes.Buffer
+	g := life.NewGame(
+		life.WithUI(life.NopUI{}),
+		life.WithGenerationRate(0),
+		life.WithMaxGenerations(3),
+		life.WithControlPipe(path),
+		life.WithLogger(log.New(&logs, "", 0)),
+	)
+
+	// the file's "pause" would otherwise hold the game up forever
+	if n := g.Run(); n != 3 {
+		t.Errorf("want: 3 generations, got: %v", n)
+	}
+	if !strings.Contains(logs.String(), "not a named pipe") {
+		t.Errorf("want: the file to be reported, got: %q", logs.String())
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("want: the file left alone, got: %v", err)
+	}
+}
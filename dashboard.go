@@ -0,0 +1,92 @@
+package life
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// dashboardHistory is how many populations the dashboard graph shows, one
+// column each
+const dashboardHistory = 40
+
+// NewDashboardUI creates a terminal UI which draws the board on the left and
+// a scrolling bar graph of the population on the right, as tall as the board,
+// with the newest population in the rightmost column. A line above gives the
+// generation, the population and the peak population shown on the graph. In
+// a game the board is drawn as any other frame, following the game's render
+// options, frame template and sparkline. The graph gains a column each time
+// the generation number advances, so redrawing a generation or stepping back
+// adds nothing, and it starts over whenever the game returns to generation
+// zero.
+func NewDashboardUI(w io.Writer) UI {
+	return &dashboardUI{TermUI: NewTerminalUI(w)}
+}
+
+type dashboardUI struct {
+	*TermUI
+	history *sparkline
+	last    int // the latest generation on the graph
+}
+
+// WriteGeneration redraws the screen with the nth generation and its
+// population history
+func (d *dashboardUI) WriteGeneration(n int, g *Generation) {
+	d.writeFrame(n, g, g.String())
+}
+
+// writeFrame redraws the screen with frame, the game's drawing of the nth
+// generation, beside the population history
+func (d *dashboardUI) writeFrame(n int, g *Generation, frame string) {
+	if d.history == nil || n == 0 {
+		d.history = newSparkline(dashboardHistory)
+		d.last = -1
+	}
+	if n > d.last {
+		d.history.record(g.Population())
+		d.last = n
+	}
+
+	d.ClearScreen()
+	d.Write(d.frame(n, frame))
+}
+
+// frame lays out the drawing of the nth generation beside the graph
+func (d *dashboardUI) frame(n int, drawing string) string {
+	board := strings.Split(strings.TrimSuffix(drawing, "\n"), "\n")
+	width := 0
+	for _, line := range board {
+		width = max(width, utf8.RuneCountInString(line))
+	}
+
+	values := d.history.ordered()
+	peak := 0
+	for _, v := range values {
+		peak = max(peak, v)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "generation %d  population %d  peak %d\n", n, values[len(values)-1], peak)
+
+	height := len(board)
+	for row, line := range board {
+		b.WriteString(line)
+		b.WriteString(strings.Repeat(" ", width-utf8.RuneCountInString(line)))
+		b.WriteString(" │")
+
+		// the bar for v fills the rows from the bottom up to its share of the
+		// peak, rounded up so any population shows
+		level := height - row
+		for _, v := range values {
+			if peak > 0 && (v*height+peak-1)/peak >= level {
+				b.WriteRune('█')
+			} else {
+				b.WriteByte(' ')
+			}
+		}
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
@@ -0,0 +1,88 @@
+package life_test
+
+import (
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/enocom/life"
+)
+
+func TestDashboardUI(t *testing.T) {
+	var buf strings.Builder
+	ui := life.NewDashboardUI(&buf).(life.GenerationUI)
+
+	d := life.Dimension{X: 2, Y: 2}
+	ui.WriteGeneration(0, newBoard(d, [2]int{0, 0}, [2]int{1, 0}, [2]int{0, 1}, [2]int{1, 1}))
+	buf.Reset()
+	ui.WriteGeneration(1, newBoard(d, [2]int{0, 0}))
+	buf.Reset()
+	ui.WriteGeneration(2, newBoard(d, [2]int{0, 0}, [2]int{1, 0}))
+
+	want := "\033[H\033[2J" +
+		"generation 2  population 2  peak 4\n" +
+		"o o │█  \n" +
+		"    │███\n"
+	if got := buf.String(); got != want {
+		t.Errorf("want: %#v, got: %#v", want, got)
+	}
+}
+
+func TestDashboardUIRedraws(t *testing.T) {
+	var buf strings.Builder
+	ui := life.NewDashboardUI(&buf).(life.GenerationUI)
+
+	d := life.Dimension{X: 2, Y: 2}
+	block := newBoard(d, [2]int{0, 0}, [2]int{1, 0}, [2]int{0, 1}, [2]int{1, 1})
+	single := newBoard(d, [2]int{0, 0})
+	ui.WriteGeneration(0, block)
+	ui.WriteGeneration(1, single)
+	// redrawing generation 1 and stepping back to it add nothing
+	ui.WriteGeneration(1, single)
+	ui.WriteGeneration(2, block)
+	buf.Reset()
+	ui.WriteGeneration(1, single)
+
+	want := "\033[H\033[2J" +
+		"generation 1  population 4  peak 4\n" +
+		"o   │█ █\n" +
+		"    │███\n"
+	if got := buf.String(); got != want {
+		t.Errorf("want: %#v, got: %#v", want, got)
+	}
+
+	// a reset starts the graph over
+	buf.Reset()
+	ui.WriteGeneration(0, single)
+	want = "\033[H\033[2J" +
+		"generation 0  population 1  peak 1\n" +
+		"o   │█\n" +
+		"    │█\n"
+	if got := buf.String(); got != want {
+		t.Errorf("want: %#v, got: %#v", want, got)
+	}
+}
+
+func TestDashboardUIInGame(t *testing.T) {
+	var buf strings.Builder
+	block := newBoard(life.Dimension{X: 2, Y: 2}, [2]int{0, 0}, [2]int{1, 0}, [2]int{0, 1}, [2]int{1, 1})
+	g := life.NewGame(
+		life.WithUI(life.NewDashboardUI(&buf)),
+		life.WithGenerationRate(0),
+		life.WithMaxGenerations(1),
+		life.WithGeneration(block),
+		life.WithRenderOptions(life.WithAxes()),
+		life.WithFrameTemplate(template.Must(template.New("").Parse("pop {{.Pop}}\n{{.Board}}"))),
+	)
+	g.Run()
+
+	frames := strings.Split(buf.String(), "\033[H\033[2J")
+	want := "generation 1  population 4  peak 4\n" +
+		"pop 4 │██\n" +
+		"  0 1 │██\n" +
+		"0 o o │██\n" +
+		"1 o o │██\n"
+	if got := frames[len(frames)-1]; got != want {
+		t.Errorf("want: %#v, got: %#v", want, got)
+	}
+}
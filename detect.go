@@ -0,0 +1,109 @@
+package life
+
+import "io"
+
+// EventKind identifies a significant transition in a running game
+type EventKind int
+
+const (
+	// Extinct means no live cells remain
+	Extinct EventKind = iota
+	// Stable means the board stopped changing, i.e. it holds a still life. An
+	// empty board is reported as Extinct instead.
+	Stable
+	// Cycle means the board returned to an earlier state, i.e. it holds an
+	// oscillator
+	Cycle
+	// Quiescent means the number of cells changing each generation fell
+	// below the threshold given to WithAutoPauseOnQuiescence, which paused
+	// the game
+	Quiescent
+)
+
+// String returns a description of the event kind
+func (k EventKind) String() string {
+	switch k {
+	case Extinct:
+		return "extinct"
+	case Stable:
+		return "stable"
+	case Cycle:
+		return "cycle"
+	case Quiescent:
+		return "quiescent"
+	default:
+		return "unknown"
+	}
+}
+
+// Event describes a significant transition in a running game
+type Event struct {
+	Kind       EventKind
+	Generation int // the generation at which the transition was detected
+	Period     int // for a Cycle, the number of generations between repeats
+}
+
+// NewBellNotifier returns a callback for WithNotify which rings the terminal
+// bell by writing "\a" to w on every event
+func NewBellNotifier(w io.Writer) func(Event) {
+	return func(Event) {
+		_, _ = w.Write([]byte("\a"))
+	}
+}
+
+// detectionHistory is how many recent generations are remembered when
+// looking for a cycle, which bounds the longest period that can be detected
+const detectionHistory = 128
+
+func newDetector() *detector {
+	return &detector{
+		seen:     make(map[string]int),
+		reported: make(map[EventKind]bool),
+	}
+}
+
+// detector watches successive generations for extinction, stability and
+// cycles, reporting each kind of event once
+type detector struct {
+	seen     map[string]int
+	order    []string
+	reported map[EventKind]bool
+}
+
+// observe records generation gen as the nth of a run and returns any event it
+// newly triggers
+func (d *detector) observe(gen *Generation, n int) (Event, bool) {
+	key := gen.QuadKey()
+	earlier, repeated := d.seen[key]
+
+	d.seen[key] = n
+	d.order = append(d.order, key)
+	if len(d.order) > detectionHistory {
+		oldest := d.order[0]
+		d.order = d.order[1:]
+		if d.seen[oldest] <= n-detectionHistory {
+			delete(d.seen, oldest)
+		}
+	}
+
+	switch {
+	case gen.Population() == 0:
+		return d.report(Event{Kind: Extinct, Generation: n})
+	case repeated && n-earlier == 1:
+		return d.report(Event{Kind: Stable, Generation: n})
+	case repeated:
+		return d.report(Event{Kind: Cycle, Generation: n, Period: n - earlier})
+	}
+
+	return Event{}, false
+}
+
+// report returns e unless an event of the same kind was already reported
+func (d *detector) report(e Event) (Event, bool) {
+	if d.reported[e.Kind] {
+		return Event{}, false
+	}
+	d.reported[e.Kind] = true
+
+	return e, true
+}
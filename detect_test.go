@@ -0,0 +1,67 @@
+package life_test
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/enocom/life"
+)
+
+func TestNotify(t *testing.T) {
+	testCases := map[string]struct {
+		seed *life.Generation
+		want []life.Event
+	}{
+		"empty": {
+			seed: newBoard(life.Dimension{X: 3, Y: 3}),
+			want: []life.Event{
+				{Kind: life.Extinct, Generation: 0},
+			},
+		},
+		"block": {
+			seed: newBoard(life.Dimension{X: 4, Y: 4},
+				[2]int{1, 1}, [2]int{2, 1}, [2]int{1, 2}, [2]int{2, 2},
+			),
+			want: []life.Event{
+				{Kind: life.Stable, Generation: 1},
+			},
+		},
+		"blinker": {
+			seed: newBoard(life.Dimension{X: 5, Y: 5},
+				[2]int{1, 2}, [2]int{2, 2}, [2]int{3, 2},
+			),
+			want: []life.Event{
+				{Kind: life.Cycle, Generation: 2, Period: 2},
+			},
+		},
+	}
+
+	for description, tc := range testCases {
+		var events []life.Event
+		g := life.NewGame(
+			life.WithUI(nil),
+			life.WithGenerationRate(0),
+			life.WithMaxGenerations(6),
+			life.WithGeneration(tc.seed),
+			life.WithNotify(func(e life.Event) {
+				events = append(events, e)
+			}),
+		)
+		g.Run()
+
+		if !reflect.DeepEqual(events, tc.want) {
+			t.Errorf("(%s): want %v, got %v", description, tc.want, events)
+		}
+	}
+}
+
+func TestBellNotifier(t *testing.T) {
+	var buf bytes.Buffer
+	notify := life.NewBellNotifier(&buf)
+
+	notify(life.Event{Kind: life.Stable})
+	if buf.String() != "\a" {
+		t.Errorf("want: %#v, got: %#v", "\a", buf.String())
+	}
+}
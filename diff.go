@@ -0,0 +1,175 @@
+package life
+
+import "errors"
+
+// ErrDimensionMismatch is returned when two generations expected to share a
+// Dimension do not
+var ErrDimensionMismatch = errors.New("life: generations have different dimensions")
+
+// CellChange records a cell whose state differs between two generations
+type CellChange struct {
+	X    int
+	Y    int
+	Cell Cell // the state of the cell in the later generation
+}
+
+// Diff returns the cells which changed between generations a and b, in index
+// order
+func Diff(a, b *Generation) ([]CellChange, error) {
+	if a.dimensions != b.dimensions {
+		return nil, ErrDimensionMismatch
+	}
+
+	var changes []CellChange
+	for i, cell := range b.cells {
+		if cell != a.cells[i] {
+			changes = append(changes, a.change(i, cell))
+		}
+	}
+
+	return changes, nil
+}
+
+// ChangeCount returns how many cells differ between generations a and b, the
+// number of changes Diff would list, without building the list. The count for
+// a board and its successor is zero for a still life and stays high while the
+// board is chaotic.
+func ChangeCount(a, b *Generation) (int, error) {
+	if a.dimensions != b.dimensions {
+		return 0, ErrDimensionMismatch
+	}
+
+	count := 0
+	for i, cell := range b.cells {
+		if cell != a.cells[i] {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// Similarity returns the fraction of cells which are in the same state in
+// generations a and b, from 0 when every cell differs to 1 when none does.
+// Boards without cells are identical.
+func Similarity(a, b *Generation) (float64, error) {
+	changed, err := ChangeCount(a, b)
+	if err != nil {
+		return 0, err
+	}
+	if len(a.cells) == 0 {
+		return 1, nil
+	}
+
+	return 1 - float64(changed)/float64(len(a.cells)), nil
+}
+
+// Step produces the next generation like Next, recording the cells which
+// changed state along the way. A still life produces no changes. Like Next,
+// Step panics if the generation is malformed.
+func Step(g *Generation) (*Generation, []CellChange) {
+	g.mustValidate()
+	clean := g.unmasked()
+
+	var (
+		cells   []Cell
+		changes []CellChange
+	)
+	for i, cell := range g.cells {
+		nextCell := NewDeadCell()
+		if !g.masked(i) {
+			nextCell = generate(i, cell, clean)
+		}
+		if nextCell != cell {
+			changes = append(changes, g.change(i, nextCell))
+		}
+		cells = append(cells, nextCell)
+	}
+
+	return g.aged(g.successor(cells)), changes
+}
+
+// change describes cell as the new state at idx
+func (g *Generation) change(idx int, c Cell) CellChange {
+	return CellChange{X: idx % g.dimensions.X, Y: idx / g.dimensions.X, Cell: c}
+}
+
+// StepStats produces the next generation like Next, counting how many cells
+// were born and how many died along the way. A still life has no births or
+// deaths, while a blinker has two of each every step. Like Next, StepStats
+// panics if the generation is malformed.
+func StepStats(g *Generation) (next *Generation, born, died int) {
+	g.mustValidate()
+	clean := g.unmasked()
+
+	cells := make([]Cell, len(g.cells))
+	for i, cell := range g.cells {
+		if !g.masked(i) {
+			cells[i] = generate(i, cell, clean)
+		}
+
+		switch {
+		case cells[i].Alive() && !cell.Alive():
+			born++
+		case !cells[i].Alive() && cell.Alive():
+			died++
+		}
+	}
+
+	return g.aged(g.successor(cells)), born, died
+}
+
+// Overlay draws a and b on top of each other in the layout of String: cells
+// alive in both are drawn as "o", cells alive only in a as "a" and cells alive
+// only in b as "b". It is useful for comparing a board with its predecessor,
+// or the same seed evolved under two rules.
+func Overlay(a, b *Generation) (string, error) {
+	if a.dimensions != b.dimensions {
+		return "", ErrDimensionMismatch
+	}
+
+	glyphs := make([]string, len(a.cells))
+	for i := range a.cells {
+		switch inA, inB := a.cells[i].Alive(), b.cells[i].Alive(); {
+		case inA && inB:
+			glyphs[i] = "o"
+		case inA:
+			glyphs[i] = "a"
+		case inB:
+			glyphs[i] = "b"
+		default:
+			glyphs[i] = " "
+		}
+	}
+
+	return a.Render(withGlyphs(glyphs)), nil
+}
+
+// CompareRules runs seed under rules a and b in lockstep for the given number
+// of steps and returns, for each generation from the seed onwards, a board
+// whose live cells are those where the two runs disagree. The first board is
+// always empty, as both runs start from seed, and the result holds steps+1
+// boards. Played in order they show how the difference between the rules
+// spreads outward. A negative number of steps is treated as zero.
+func CompareRules(seed *Generation, a, b Rule, steps int) []*Generation {
+	steps = max(steps, 0)
+	runA, runB := seed.successor(seed.cells), seed.successor(seed.cells)
+	runA.rule, runB.rule = a, b
+
+	diffs := make([]*Generation, 0, steps+1)
+	for n := 0; n <= steps; n++ {
+		if n > 0 {
+			runA, runB = Next(runA), Next(runB)
+		}
+
+		cells := make([]Cell, len(seed.cells))
+		for i := range cells {
+			cells[i] = Cell{alive: runA.cells[i] != runB.cells[i]}
+		}
+		diff := seed.successor(cells)
+		diff.rule = Conway
+		diffs = append(diffs, diff)
+	}
+
+	return diffs
+}
@@ -0,0 +1,272 @@
+package life_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/enocom/life"
+)
+
+func TestStepBlinker(t *testing.T) {
+	// - - -
+	// o o o
+	// - - -
+	g := newBoard(life.Dimension{X: 3, Y: 3}, [2]int{0, 1}, [2]int{1, 1}, [2]int{2, 1})
+
+	next, changes := life.Step(g)
+
+	want := []life.CellChange{
+		{X: 1, Y: 0, Cell: life.NewLiveCell()},
+		{X: 0, Y: 1, Cell: life.NewDeadCell()},
+		{X: 2, Y: 1, Cell: life.NewDeadCell()},
+		{X: 1, Y: 2, Cell: life.NewLiveCell()},
+	}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("want: %v, got: %v", want, changes)
+	}
+
+	if !equal(next.Cells(), life.Next(g).Cells()) {
+		t.Errorf("want: %v, got: %v", life.Next(g).Cells(), next.Cells())
+	}
+
+	diff, err := life.Diff(g, next)
+	if err != nil || !reflect.DeepEqual(diff, changes) {
+		t.Errorf("want: %v, got: %v (err = %v)", changes, diff, err)
+	}
+}
+
+func TestStepStillLife(t *testing.T) {
+	g := newBoard(life.Dimension{X: 4, Y: 4}, [2]int{1, 1}, [2]int{2, 1}, [2]int{1, 2}, [2]int{2, 2})
+
+	_, changes := life.Step(g)
+	if len(changes) != 0 {
+		t.Errorf("want: no changes, got: %v", changes)
+	}
+}
+
+func TestDiffDimensionMismatch(t *testing.T) {
+	a := newBoard(life.Dimension{X: 2, Y: 2})
+	b := newBoard(life.Dimension{X: 3, Y: 3})
+
+	if _, err := life.Diff(a, b); err != life.ErrDimensionMismatch {
+		t.Errorf("want: %v, got: %v", life.ErrDimensionMismatch, err)
+	}
+}
+
+func TestStepStats(t *testing.T) {
+	testCases := map[string]struct {
+		seed       *life.Generation
+		born, died int
+	}{
+		"blinker": {
+			seed: newBoard(life.Dimension{X: 3, Y: 3}, [2]int{0, 1}, [2]int{1, 1}, [2]int{2, 1}),
+			born: 2,
+			died: 2,
+		},
+		"block": {
+			seed: newBoard(life.Dimension{X: 4, Y: 4},
+				[2]int{1, 1}, [2]int{2, 1}, [2]int{1, 2}, [2]int{2, 2},
+			),
+		},
+		"lone cell": {
+			seed: newBoard(life.Dimension{X: 3, Y: 3}, [2]int{1, 1}),
+			died: 1,
+		},
+	}
+
+	for description, tc := range testCases {
+		next, born, died := life.StepStats(tc.seed)
+		if born != tc.born || died != tc.died {
+			t.Errorf("(%s): want %v born and %v died, got %v and %v",
+				description, tc.born, tc.died, born, died)
+		}
+
+		if !equal(next.Cells(), life.Next(tc.seed).Cells()) {
+			t.Errorf("(%s): want %v, got %v", description, life.Next(tc.seed), next)
+		}
+	}
+}
+
+// maskedCentre returns a 3x3 board with a live top row and its centre, which
+// the row would otherwise bring to life, masked out
+func maskedCentre() *life.Generation {
+	d := life.Dimension{X: 3, Y: 3}
+	return life.NewGeneration(
+		life.WithDimension(d),
+		life.WithCells(newBoard(d, [2]int{0, 0}, [2]int{1, 0}, [2]int{2, 0}).Cells()),
+		life.WithMask(func(x, y int) bool { return x != 1 || y != 1 }),
+	)
+}
+
+func TestStepMasked(t *testing.T) {
+	g := maskedCentre()
+
+	next, changes := life.Step(g)
+	if want := life.Next(g); !equal(next.Cells(), want.Cells()) {
+		t.Errorf("want: %v, got: %v", want, next)
+	}
+	for _, c := range changes {
+		if c.X == 1 && c.Y == 1 {
+			t.Errorf("want: no change to the masked cell, got: %+v", c)
+		}
+	}
+}
+
+func TestMaskedCellsSetAlive(t *testing.T) {
+	// a cell made alive outside the mask is not counted by any of its
+	// neighbors, so every stepper produces what the board gives without it
+	want := life.Next(maskedCentre())
+	g := maskedCentre()
+	if err := g.Set(1, 1, true); err != nil {
+		t.Fatal(err)
+	}
+
+	stepped, _ := life.Step(g)
+	stats, _, _ := life.StepStats(g)
+	incremental, _ := life.NextIncremental(g, nil)
+	for description, got := range map[string]*life.Generation{
+		"Next":            life.Next(g),
+		"NextWith":        life.NextWith(g, conway),
+		"Step":            stepped,
+		"StepStats":       stats,
+		"NextIncremental": incremental,
+	} {
+		if !equal(got.Cells(), want.Cells()) {
+			t.Errorf("(%s): want: %#v, got: %#v", description, want.String(), got.String())
+		}
+	}
+	if counts := g.NeighborCounts(); counts[3] != 2 {
+		t.Errorf("want: 2 live neighbors beside the masked cell, got: %v", counts[3])
+	}
+}
+
+func TestStepStatsMasked(t *testing.T) {
+	next, born, died := life.StepStats(maskedCentre())
+
+	// the ends of the row die and the middle survives, with nothing born
+	if born != 0 || died != 2 {
+		t.Errorf("want: 0 born and 2 died, got: %v born and %v died", born, died)
+	}
+	if next.Cells()[4].Alive() {
+		t.Errorf("want: the masked cell to stay dead, got: %v", next)
+	}
+}
+
+func TestOverlay(t *testing.T) {
+	a := newBoard(life.Dimension{X: 3, Y: 1}, [2]int{0, 0}, [2]int{1, 0})
+	b := newBoard(life.Dimension{X: 3, Y: 1}, [2]int{1, 0}, [2]int{2, 0})
+
+	got, err := life.Overlay(a, b)
+	if err != nil {
+		t.Fatalf("want: no error, got: %v", err)
+	}
+
+	want := "a o b\n"
+	if got != want {
+		t.Errorf("want: %#v, got: %#v", want, got)
+	}
+
+	if _, err := life.Overlay(a, newBoard(life.Dimension{X: 1, Y: 3})); err != life.ErrDimensionMismatch {
+		t.Errorf("want: %v, got: %v", life.ErrDimensionMismatch, err)
+	}
+}
+
+func TestChangeCount(t *testing.T) {
+	blinker := newBoard(life.Dimension{X: 3, Y: 3}, [2]int{0, 1}, [2]int{1, 1}, [2]int{2, 1})
+	block := newBoard(life.Dimension{X: 4, Y: 4}, [2]int{1, 1}, [2]int{2, 1}, [2]int{1, 2}, [2]int{2, 2})
+
+	testCases := map[string]struct {
+		a, b *life.Generation
+		want int
+	}{
+		"blinker": {a: blinker, b: life.Next(blinker), want: 4},
+		"block":   {a: block, b: life.Next(block), want: 0},
+	}
+
+	for description, tc := range testCases {
+		got, err := life.ChangeCount(tc.a, tc.b)
+		if err != nil || got != tc.want {
+			t.Errorf("(%s): want: %v, got: %v (err = %v)", description, tc.want, got, err)
+		}
+	}
+
+	if _, err := life.ChangeCount(blinker, block); err != life.ErrDimensionMismatch {
+		t.Errorf("want: %v, got: %v", life.ErrDimensionMismatch, err)
+	}
+}
+
+func TestSimilarity(t *testing.T) {
+	blinker := newBoard(life.Dimension{X: 3, Y: 3}, [2]int{0, 1}, [2]int{1, 1}, [2]int{2, 1})
+
+	got, err := life.Similarity(blinker, life.Next(blinker))
+	if want := 5.0 / 9; err != nil || got != want {
+		t.Errorf("want: %v, got: %v (err = %v)", want, got, err)
+	}
+	if got, _ := life.Similarity(blinker, blinker); got != 1 {
+		t.Errorf("want: 1, got: %v", got)
+	}
+	if _, err := life.Similarity(blinker, newBoard(life.Dimension{X: 4, Y: 4})); err != life.ErrDimensionMismatch {
+		t.Errorf("want: %v, got: %v", life.ErrDimensionMismatch, err)
+	}
+}
+
+func TestCompareRules(t *testing.T) {
+	highLife, err := life.ParseRule("B36/S23")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a dead cell with six live neighbors is born only under HighLife:
+	//
+	// o o .
+	// . . o
+	// o o o
+	d := life.Dimension{X: 3, Y: 3}
+	seed := newBoard(d, [2]int{0, 0}, [2]int{1, 0}, [2]int{2, 1}, [2]int{0, 2}, [2]int{1, 2}, [2]int{2, 2})
+
+	diffs := life.CompareRules(seed, life.Conway, highLife, 2)
+	if len(diffs) != 3 {
+		t.Fatalf("want: 3 boards, got: %v", len(diffs))
+	}
+	if !diffs[0].IsEmpty() {
+		t.Errorf("want: no difference at the start, got: %#v", diffs[0].String())
+	}
+
+	want := newBoard(d, [2]int{1, 1})
+	if !equal(diffs[1].Cells(), want.Cells()) {
+		t.Errorf("want: %#v, got: %#v", want.String(), diffs[1].String())
+	}
+
+	a, b := life.Next(life.Next(seed)), life.Next(life.Next(life.NewGeneration(
+		life.WithDimension(d), life.WithCells(seed.Cells()), life.WithRule(highLife),
+	)))
+	if got, _ := life.ChangeCount(a, b); got != diffs[2].Population() {
+		t.Errorf("want: %v differing cells, got: %v", got, diffs[2].Population())
+	}
+
+	if diffs := life.CompareRules(seed, life.Conway, highLife, -5); len(diffs) != 1 || !diffs[0].IsEmpty() {
+		t.Errorf("want: just the empty first board for negative steps, got: %v boards", len(diffs))
+	}
+}
+
+func TestStepMalformed(t *testing.T) {
+	g := life.NewGeneration(life.WithDimension(life.Dimension{X: -1, Y: 2}))
+	_, want := life.NextErr(g)
+	if want == nil {
+		t.Fatal("want: an error from NextErr")
+	}
+
+	for description, step := range map[string]func(){
+		"Step":      func() { life.Step(g) },
+		"StepStats": func() { life.StepStats(g) },
+	} {
+		func() {
+			defer func() {
+				if got, ok := recover().(error); !ok || got.Error() != want.Error() {
+					t.Errorf("(%s): want: a panic with %q, got: %v", description, want, got)
+				}
+			}()
+			step()
+		}()
+	}
+}
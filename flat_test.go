@@ -0,0 +1,60 @@
+package life_test
+
+import (
+	"testing"
+
+	"github.com/enocom/life"
+)
+
+// conway is Conway's rule written cell by cell, for NextWith
+func conway(c life.Cell, liveNeighbors int) life.Cell {
+	if liveNeighbors == 3 || (c.Alive() && liveNeighbors == 2) {
+		return life.NewLiveCell()
+	}
+	return life.NewDeadCell()
+}
+
+func TestNextMatchesNextWith(t *testing.T) {
+	sizes := []life.Dimension{{X: 1, Y: 1}, {X: 5, Y: 1}, {X: 1, Y: 5}, {X: 2, Y: 3}, {X: 7, Y: 4}, {X: 32, Y: 24}}
+	variants := map[string][]life.Option{
+		"plain":         nil,
+		"live boundary": {life.WithBoundaryState(life.NewLiveCell())},
+		"wrap":          {life.WithWrap()},
+		"mask":          {life.WithMask(func(x, y int) bool { return (x+y)%3 != 0 })},
+	}
+
+	for description, variant := range variants {
+		for _, d := range sizes {
+			for seed := int64(0); seed < 4; seed++ {
+				opts := append([]life.Option{life.WithDimension(d), life.WithRandomSeed(seed)}, variant...)
+				g := life.NewGeneration(opts...)
+
+				got, want := life.Next(g), life.NextWith(g, conway)
+				if !equal(got.Cells(), want.Cells()) {
+					t.Errorf("(%s, %v, seed %d): want: %#v, got: %#v", description, d, seed, want.String(), got.String())
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkNext1024(b *testing.B) {
+	g := life.NewGeneration(
+		life.WithDimension(life.Dimension{X: 1024, Y: 1024}),
+		life.WithRandomSeed(1),
+	)
+
+	for _, bm := range []struct {
+		name string
+		next func(*life.Generation) *life.Generation
+	}{
+		{name: "flat", next: life.Next},
+		{name: "cells", next: func(g *life.Generation) *life.Generation { return life.NextWith(g, conway) }},
+	} {
+		b.Run(bm.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				bm.next(g)
+			}
+		})
+	}
+}
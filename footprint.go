@@ -0,0 +1,49 @@
+package life
+
+// WithFootprint records every cell which is alive at any point while the game
+// runs, for Footprint to report
+func WithFootprint() GameOption {
+	return func(g *Game) {
+		g.trackFootprint = true
+	}
+}
+
+// Footprint returns a generation in which each cell is alive if it was alive
+// in any generation of the run so far, showing the whole area a pattern
+// reached; a glider leaves a diagonal streak. It returns nil unless the game
+// was created with WithFootprint and has started. It is safe to call while the
+// game runs.
+func (g *Game) Footprint() *Generation {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.footprint == nil {
+		return nil
+	}
+
+	return g.footprint.successor(append([]Cell(nil), g.footprint.cells...))
+}
+
+// recordFootprint adds the live cells of gen to the footprint, following the
+// board when it is resized
+func (g *Game) recordFootprint(gen *Generation) {
+	if !g.trackFootprint {
+		return
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	switch {
+	case g.footprint == nil:
+		g.footprint = gen.successor(make([]Cell, len(gen.cells)))
+	case g.footprint.dimensions != gen.dimensions:
+		g.footprint = g.footprint.Resize(gen.dimensions)
+	}
+
+	for i, c := range gen.cells {
+		if c.Alive() {
+			g.footprint.cells[i] = NewLiveCell()
+		}
+	}
+}
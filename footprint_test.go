@@ -0,0 +1,54 @@
+package life_test
+
+import (
+	"testing"
+
+	"github.com/enocom/life"
+)
+
+func TestFootprint(t *testing.T) {
+	d := life.Dimension{X: 8, Y: 8}
+	glider := newBoard(d, [2]int{1, 0}, [2]int{2, 1}, [2]int{0, 2}, [2]int{1, 2}, [2]int{2, 2})
+	g := life.NewGame(
+		life.WithUI(nil),
+		life.WithGenerationRate(0),
+		life.WithMaxGenerations(8),
+		life.WithGeneration(glider),
+		life.WithFootprint(),
+	)
+	g.Run()
+
+	footprint := g.Footprint()
+	if footprint.Dimension() != d {
+		t.Fatalf("want: %v, got: %v", d, footprint.Dimension())
+	}
+
+	touched := map[int]bool{}
+	gen := glider
+	for i := 0; i <= 8; i++ {
+		for idx, c := range gen.Cells() {
+			if c.Alive() {
+				touched[idx] = true
+			}
+		}
+		gen = life.Next(gen)
+	}
+	for idx, c := range footprint.Cells() {
+		if c.Alive() != touched[idx] {
+			t.Errorf("cell %d: want alive: %v, got: %v\n%v", idx, touched[idx], c.Alive(), footprint)
+		}
+	}
+}
+
+func TestFootprintDisabled(t *testing.T) {
+	g := life.NewGame(
+		life.WithUI(nil),
+		life.WithGenerationRate(0),
+		life.WithMaxGenerations(1),
+	)
+	g.Run()
+
+	if g.Footprint() != nil {
+		t.Error("want: no footprint without WithFootprint")
+	}
+}
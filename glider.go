@@ -0,0 +1,108 @@
+package life
+
+// gliderPhases are the four phases of a glider travelling down and to the
+// right. The rotations and reflections of these cover every glider.
+var gliderPhases = []string{
+	".o." + "..o" + "ooo",
+	"o.o" + ".oo" + ".o.",
+	"..o" + "o.o" + ".oo",
+	"o.." + ".oo" + "oo.",
+}
+
+// gliderMasks holds each phase of a glider in each direction as a bitmask of
+// its 3x3 bounding box, read left to right and top to bottom
+var gliderMasks = func() map[uint16]bool {
+	masks := make(map[uint16]bool)
+	for _, phase := range gliderPhases {
+		cells := make([]Cell, len(phase))
+		for i, r := range phase {
+			cells[i] = Cell{alive: r == 'o'}
+		}
+		g := NewGeneration(WithDimension(Dimension{X: 3, Y: 3}), WithCells(cells))
+		for _, t := range g.transforms() {
+			masks[t.boxMask(0, 0)] = true
+		}
+	}
+
+	return masks
+}()
+
+// CountGliders returns the number of gliders on the board. A glider is counted
+// when the 3x3 box at some position holds one of its phases and every cell
+// bordering that box is dead, so a glider touching other live cells is not
+// counted. Candidates are considered in reading order, top to bottom and then
+// left to right, and a candidate sharing a live cell with one already counted
+// is skipped, keeping the count stable however the cells are arranged.
+func (g *Generation) CountGliders() int {
+	claimed := make([]bool, len(g.cells))
+	count := 0
+	for y := 0; y+3 <= g.dimensions.Y; y++ {
+		for x := 0; x+3 <= g.dimensions.X; x++ {
+			if !gliderMasks[g.boxMask(x, y)] || !g.isolated(x, y) || g.claim(claimed, x, y) {
+				continue
+			}
+			count++
+		}
+	}
+
+	return count
+}
+
+// boxMask returns the 3x3 box of cells with its top left corner at (x, y) as a
+// bitmask, read left to right and top to bottom
+func (g *Generation) boxMask(x, y int) uint16 {
+	var m uint16
+	for dy := 0; dy < 3; dy++ {
+		for dx := 0; dx < 3; dx++ {
+			m <<= 1
+			if g.cells[x+dx+(y+dy)*g.dimensions.X].Alive() {
+				m |= 1
+			}
+		}
+	}
+
+	return m
+}
+
+// isolated reports whether every cell on the board bordering the 3x3 box with
+// its top left corner at (x, y) is dead
+func (g *Generation) isolated(x, y int) bool {
+	for by := y - 1; by <= y+3; by++ {
+		for bx := x - 1; bx <= x+3; bx++ {
+			inside := bx >= x && bx < x+3 && by >= y && by < y+3
+			if inside || bx < 0 || by < 0 || bx >= g.dimensions.X || by >= g.dimensions.Y {
+				continue
+			}
+			if g.cells[bx+by*g.dimensions.X].Alive() {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// claim marks the live cells of the 3x3 box with its top left corner at (x,
+// y) as claimed, reporting whether any of them had already been claimed, in
+// which case nothing is marked
+func (g *Generation) claim(claimed []bool, x, y int) bool {
+	var live []int
+	for dy := 0; dy < 3; dy++ {
+		for dx := 0; dx < 3; dx++ {
+			idx := x + dx + (y+dy)*g.dimensions.X
+			if !g.cells[idx].Alive() {
+				continue
+			}
+			if claimed[idx] {
+				return true
+			}
+			live = append(live, idx)
+		}
+	}
+
+	for _, idx := range live {
+		claimed[idx] = true
+	}
+
+	return false
+}
@@ -0,0 +1,63 @@
+package life_test
+
+import (
+	"testing"
+
+	"github.com/enocom/life"
+)
+
+func TestCountGliders(t *testing.T) {
+	d := life.Dimension{X: 12, Y: 12}
+	testCases := map[string]struct {
+		live [][2]int
+		want int
+	}{
+		"empty": {
+			want: 0,
+		},
+		"one glider": {
+			live: [][2]int{{2, 1}, {3, 2}, {1, 3}, {2, 3}, {3, 3}},
+			want: 1,
+		},
+		"two gliders heading different ways": {
+			live: [][2]int{
+				{2, 1}, {3, 2}, {1, 3}, {2, 3}, {3, 3},
+				// heading up and to the left
+				{7, 7}, {8, 7}, {9, 7}, {7, 8}, {8, 9},
+			},
+			want: 2,
+		},
+		"glider on the edge of the board": {
+			live: [][2]int{{1, 0}, {2, 1}, {0, 2}, {1, 2}, {2, 2}},
+			want: 1,
+		},
+		"glider touching a block": {
+			live: [][2]int{
+				{2, 1}, {3, 2}, {1, 3}, {2, 3}, {3, 3},
+				{4, 4}, {5, 4}, {4, 5}, {5, 5},
+			},
+			want: 0,
+		},
+		"block": {
+			live: [][2]int{{4, 4}, {5, 4}, {4, 5}, {5, 5}},
+			want: 0,
+		},
+	}
+
+	for description, tc := range testCases {
+		g := newBoard(d, tc.live...)
+		if got := g.CountGliders(); got != tc.want {
+			t.Errorf("(%s): want: %v, got: %v", description, tc.want, got)
+		}
+	}
+}
+
+func TestCountGlidersEveryPhase(t *testing.T) {
+	g := newBoard(life.Dimension{X: 12, Y: 12}, [2]int{2, 1}, [2]int{3, 2}, [2]int{1, 3}, [2]int{2, 3}, [2]int{3, 3})
+	for i := 0; i < 12; i++ {
+		if got := g.CountGliders(); got != 1 {
+			t.Fatalf("generation %d: want: 1, got: %v\n%v", i, got, g)
+		}
+		g = life.Next(g)
+	}
+}
@@ -0,0 +1,39 @@
+package life
+
+import "fmt"
+
+// Grid returns a copy of the board as rows of cells, indexed [y][x], where
+// true marks a live cell
+func (g *Generation) Grid() [][]bool {
+	grid := make([][]bool, g.dimensions.Y)
+	for y := range grid {
+		grid[y] = make([]bool, g.dimensions.X)
+		for x := range grid[y] {
+			grid[y][x] = g.cells[x+y*g.dimensions.X].Alive()
+		}
+	}
+
+	return grid
+}
+
+// FromGrid builds a generation from rows of cells indexed [y][x], where true
+// marks a live cell. The Dimension is taken from the shape of the grid, so
+// every row must be the same length.
+func FromGrid(grid [][]bool) (*Generation, error) {
+	d := Dimension{Y: len(grid)}
+	if d.Y > 0 {
+		d.X = len(grid[0])
+	}
+
+	cells := make([]Cell, 0, d.X*d.Y)
+	for y, row := range grid {
+		if len(row) != d.X {
+			return nil, fmt.Errorf("life: grid row %d has %d cells, want %d", y, len(row), d.X)
+		}
+		for _, alive := range row {
+			cells = append(cells, Cell{alive: alive})
+		}
+	}
+
+	return NewGeneration(WithDimension(d), WithCells(cells)), nil
+}
@@ -0,0 +1,56 @@
+package life_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/enocom/life"
+)
+
+func TestGrid(t *testing.T) {
+	g := newBoard(life.Dimension{X: 3, Y: 2}, [2]int{0, 0}, [2]int{2, 1})
+
+	grid := g.Grid()
+	want := [][]bool{
+		{true, false, false},
+		{false, false, true},
+	}
+	if !reflect.DeepEqual(grid, want) {
+		t.Errorf("want: %v, got: %v", want, grid)
+	}
+
+	grid[0][0] = false
+	if !g.Cells()[0].Alive() {
+		t.Error("want: grid not to alias the generation")
+	}
+}
+
+func TestFromGrid(t *testing.T) {
+	grid := [][]bool{
+		{true, false, false},
+		{false, false, true},
+	}
+
+	g, err := life.FromGrid(grid)
+	if err != nil {
+		t.Fatalf("want: no error, got: %v", err)
+	}
+
+	if d := (life.Dimension{X: 3, Y: 2}); g.Dimension() != d {
+		t.Errorf("want: %v, got: %v", d, g.Dimension())
+	}
+	if !reflect.DeepEqual(g.Grid(), grid) {
+		t.Errorf("want: %v, got: %v", grid, g.Grid())
+	}
+}
+
+func TestFromGridRagged(t *testing.T) {
+	grid := [][]bool{
+		{true, false},
+		{false},
+	}
+
+	if _, err := life.FromGrid(grid); err == nil {
+		t.Error("want: an error for a ragged grid, got: nil")
+	}
+}
@@ -0,0 +1,333 @@
+//go:build go1.24
+
+package life
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// gRPC status codes used by the Life service
+const (
+	grpcOK              = 0
+	grpcInvalidArgument = 3
+	grpcUnimplemented   = 12
+)
+
+// grpcMaxMessage is the largest request message the Life service accepts
+const grpcMaxMessage = 1 << 20
+
+// Commands of a ControlRequest, as numbered in proto/life.proto
+const (
+	controlPause  = 1
+	controlResume = 2
+	controlStep   = 3
+	controlReset  = 4
+)
+
+// ServeGRPC serves the Life service described by proto/life.proto over
+// unencrypted HTTP/2 on addr, so the game can be watched and controlled by
+// any gRPC client:
+//
+//   - Watch streams a Frame for the current generation and then for every
+//     generation after it, until the game finishes or the client cancels.
+//     A client which falls behind misses frames rather than slowing the game,
+//     but the latest generation, including the final one, always arrives.
+//   - Control pauses, resumes, steps or resets the game. A step waits for
+//     the generation it produces; stepping a game which is not paused has
+//     no effect.
+//
+// ServeGRPC returns nil once the game has finished, having ended every
+// stream cleanly, or an error if it cannot listen on addr. It is implemented
+// on the standard library, so it needs Go 1.24 or later but no generated
+// code; clients can be generated from the .proto file as usual.
+func (g *Game) ServeGRPC(addr string) error {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	var protocols http.Protocols
+	protocols.SetUnencryptedHTTP2(true)
+	srv := &http.Server{Handler: http.HandlerFunc(g.serveGRPC), Protocols: &protocols}
+
+	served := make(chan struct{})
+	defer close(served)
+	go func() {
+		select {
+		case <-g.finished:
+		case <-g.stop:
+		case <-served:
+			return
+		}
+		// streams end of their own accord once the game is over
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(ctx)
+	}()
+
+	if err := srv.Serve(l); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
+
+// serveGRPC routes a gRPC call to its method
+func (g *Game) serveGRPC(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.ProtoMajor != 2 ||
+		!strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc") {
+		http.Error(w, "life: gRPC requests only", http.StatusUnsupportedMediaType)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/grpc")
+	w.WriteHeader(http.StatusOK)
+
+	switch r.URL.Path {
+	case "/life.Life/Watch":
+		g.grpcWatch(w, r)
+	case "/life.Life/Control":
+		g.grpcControl(w, r)
+	default:
+		grpcStatus(w, grpcUnimplemented, "unknown method "+r.URL.Path)
+	}
+}
+
+// grpcWatch streams a frame for each generation until the game finishes or
+// the client goes away
+func (g *Game) grpcWatch(w http.ResponseWriter, r *http.Request) {
+	if _, err := readGRPCMessage(r.Body); err != nil {
+		grpcStatus(w, grpcInvalidArgument, err.Error())
+		return
+	}
+
+	frames := g.subscribe()
+	defer g.unsubscribe(frames)
+
+	flusher, _ := w.(http.Flusher)
+	for {
+		s, ok := g.nextFrame(r.Context(), frames)
+		if !ok {
+			break
+		}
+		if err := writeGRPCMessage(w, encodeFrame(s.n, s.gen)); err != nil {
+			// the client is gone, so there is no one to tell
+			return
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+	if r.Context().Err() == nil {
+		grpcStatus(w, grpcOK, "")
+	}
+}
+
+// grpcControl applies a command to the game and replies with the number of
+// the generation showing afterwards
+func (g *Game) grpcControl(w http.ResponseWriter, r *http.Request) {
+	msg, err := readGRPCMessage(r.Body)
+	if err != nil {
+		grpcStatus(w, grpcInvalidArgument, err.Error())
+		return
+	}
+	command, err := decodeControlRequest(msg)
+	if err != nil {
+		grpcStatus(w, grpcInvalidArgument, err.Error())
+		return
+	}
+
+	switch command {
+	case controlPause:
+		g.Pause()
+	case controlResume:
+		g.Resume()
+	case controlStep:
+		g.stepAndWait(r.Context())
+	case controlReset:
+		g.Reset()
+	default:
+		grpcStatus(w, grpcInvalidArgument, fmt.Sprintf("unknown command %d", command))
+		return
+	}
+
+	g.mu.Lock()
+	n := g.currentN
+	g.mu.Unlock()
+
+	var reply []byte
+	if n != 0 {
+		reply = binary.AppendUvarint(append(reply, 0x08), uint64(n))
+	}
+	if err := writeGRPCMessage(w, reply); err != nil {
+		return
+	}
+	grpcStatus(w, grpcOK, "")
+}
+
+// stepAndWait steps a paused game and waits until the generation the step
+// produces is current, or until ctx is done or the game is over
+func (g *Game) stepAndWait(ctx context.Context) {
+	frames := g.subscribe()
+	defer g.unsubscribe(frames)
+
+	g.mu.Lock()
+	paused, before := g.resumed != nil, g.currentN
+	g.mu.Unlock()
+	if !paused {
+		return
+	}
+	g.Step()
+
+	for {
+		s, ok := g.nextFrame(ctx, frames)
+		if !ok || s.n != before {
+			return
+		}
+	}
+}
+
+// nextFrame waits for the next frame sent to a channel from subscribe. Frames
+// sent before the game finished or stopped are still returned, so the last
+// generations are never lost to the end of the game. The second return value
+// is false once ctx is done, or the game is over and every frame sent has been
+// returned.
+func (g *Game) nextFrame(ctx context.Context, frames chan snapshot) (snapshot, bool) {
+	select {
+	case s := <-frames:
+		return s, true
+	case <-ctx.Done():
+		return snapshot{}, false
+	case <-g.finished:
+	case <-g.stop:
+	}
+
+	select {
+	case s := <-frames:
+		return s, true
+	default:
+		return snapshot{}, false
+	}
+}
+
+// grpcStatus ends a call with a gRPC status, sent as trailers
+func grpcStatus(w http.ResponseWriter, code int, message string) {
+	w.Header().Set(http.TrailerPrefix+"Grpc-Status", strconv.Itoa(code))
+	if message != "" {
+		w.Header().Set(http.TrailerPrefix+"Grpc-Message", message)
+	}
+}
+
+// readGRPCMessage reads a single length-prefixed message from a request
+func readGRPCMessage(r io.Reader) ([]byte, error) {
+	var prefix [5]byte
+	if _, err := io.ReadFull(r, prefix[:]); err != nil {
+		return nil, fmt.Errorf("reading message: %v", err)
+	}
+	if prefix[0] != 0 {
+		return nil, errors.New("compressed messages are not supported")
+	}
+	size := binary.BigEndian.Uint32(prefix[1:])
+	if size > grpcMaxMessage {
+		return nil, fmt.Errorf("message of %d bytes is too large", size)
+	}
+
+	msg := make([]byte, size)
+	if _, err := io.ReadFull(r, msg); err != nil {
+		return nil, fmt.Errorf("reading message: %v", err)
+	}
+
+	return msg, nil
+}
+
+// writeGRPCMessage writes msg with the length prefix gRPC puts before every
+// message
+func writeGRPCMessage(w io.Writer, msg []byte) error {
+	prefix := make([]byte, 5, 5+len(msg))
+	binary.BigEndian.PutUint32(prefix[1:], uint32(len(msg)))
+	_, err := w.Write(append(prefix, msg...))
+
+	return err
+}
+
+// encodeFrame encodes the nth generation as a Frame message, leaving out the
+// fields which are zero, as protobuf does
+func encodeFrame(n int, gen *Generation) []byte {
+	var b []byte
+	varint := func(field int, v uint64) {
+		if v != 0 {
+			b = binary.AppendUvarint(append(b, byte(field<<3)), v)
+		}
+	}
+	varint(1, uint64(n))
+	varint(2, uint64(gen.dimensions.X))
+	varint(3, uint64(gen.dimensions.Y))
+
+	cells := make([]byte, (len(gen.cells)+7)/8)
+	for i, c := range gen.cells {
+		if c.Alive() {
+			cells[i/8] |= 0x80 >> uint(i%8)
+		}
+	}
+	if len(cells) > 0 {
+		b = append(b, 4<<3|2)
+		b = binary.AppendUvarint(b, uint64(len(cells)))
+		b = append(b, cells...)
+	}
+
+	return b
+}
+
+// decodeControlRequest returns the command of a ControlRequest message,
+// skipping any fields it does not know
+func decodeControlRequest(msg []byte) (int, error) {
+	command := 0
+	for len(msg) > 0 {
+		key, n := binary.Uvarint(msg)
+		if n <= 0 {
+			return 0, errors.New("malformed ControlRequest")
+		}
+		msg = msg[n:]
+
+		field, wireType := key>>3, key&7
+		switch wireType {
+		case 0:
+			v, n := binary.Uvarint(msg)
+			if n <= 0 {
+				return 0, errors.New("malformed ControlRequest")
+			}
+			msg = msg[n:]
+			if field == 1 {
+				command = int(v)
+			}
+		case 1, 5:
+			size := 8
+			if wireType == 5 {
+				size = 4
+			}
+			if len(msg) < size {
+				return 0, errors.New("malformed ControlRequest")
+			}
+			msg = msg[size:]
+		case 2:
+			size, n := binary.Uvarint(msg)
+			if n <= 0 || uint64(len(msg)-n) < size {
+				return 0, errors.New("malformed ControlRequest")
+			}
+			msg = msg[n+int(size):]
+		default:
+			return 0, errors.New("malformed ControlRequest")
+		}
+	}
+
+	return command, nil
+}
@@ -0,0 +1,268 @@
+//go:build go1.24
+
+package life_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/enocom/life"
+)
+
+// grpcClient calls a Life service over unencrypted HTTP/2
+type grpcClient struct {
+	t    *testing.T
+	addr string
+	http *http.Client
+}
+
+func newGRPCClient(t *testing.T, addr string) *grpcClient {
+	var protocols http.Protocols
+	protocols.SetUnencryptedHTTP2(true)
+
+	return &grpcClient{t: t, addr: addr, http: &http.Client{Transport: &http.Transport{Protocols: &protocols}}}
+}
+
+// call starts a call to method with a single request message
+func (c *grpcClient) call(ctx context.Context, method string, msg []byte) *http.Response {
+	c.t.Helper()
+
+	body := make([]byte, 5, 5+len(msg))
+	binary.BigEndian.PutUint32(body[1:], uint32(len(msg)))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		"http://"+c.addr+"/life.Life/"+method, bytes.NewReader(append(body, msg...)))
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/grpc")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+
+	return resp
+}
+
+// control sends a command and returns the generation in the reply
+func (c *grpcClient) control(command byte) int {
+	c.t.Helper()
+
+	resp := c.call(context.Background(), "Control", []byte{0x08, command})
+	defer resp.Body.Close()
+	reply := readMessage(c.t, resp.Body)
+	io.Copy(io.Discard, resp.Body)
+	if status := resp.Trailer.Get("Grpc-Status"); status != "0" {
+		c.t.Fatalf("want: status 0, got: %q (%s)", status, resp.Trailer.Get("Grpc-Message"))
+	}
+
+	fields := decodeFields(c.t, reply)
+	return int(fields[1].n)
+}
+
+// readMessage reads a single length-prefixed message
+func readMessage(t *testing.T, r io.Reader) []byte {
+	t.Helper()
+
+	var prefix [5]byte
+	if _, err := io.ReadFull(r, prefix[:]); err != nil {
+		t.Fatal(err)
+	}
+	msg := make([]byte, binary.BigEndian.Uint32(prefix[1:]))
+	if _, err := io.ReadFull(r, msg); err != nil {
+		t.Fatal(err)
+	}
+
+	return msg
+}
+
+// field is a decoded protobuf field, either a varint or bytes
+type field struct {
+	n     uint64
+	bytes []byte
+}
+
+// decodeFields decodes a message of varint and length-delimited fields
+func decodeFields(t *testing.T, msg []byte) map[uint64]field {
+	t.Helper()
+
+	fields := make(map[uint64]field)
+	for len(msg) > 0 {
+		key, n := binary.Uvarint(msg)
+		msg = msg[n:]
+		v, n := binary.Uvarint(msg)
+		msg = msg[n:]
+		if key&7 == 2 {
+			fields[key>>3] = field{bytes: msg[:v]}
+			msg = msg[v:]
+			continue
+		}
+		fields[key>>3] = field{n: v}
+	}
+
+	return fields
+}
+
+// freeAddr returns a local address with a port nothing is listening on
+func freeAddr(t *testing.T) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	return l.Addr().String()
+}
+
+func TestServeGRPC(t *testing.T) {
+	blinker := newBoard(life.Dimension{X: 3, Y: 3}, [2]int{0, 1}, [2]int{1, 1}, [2]int{2, 1})
+	ui := &pausingUI{after: 1}
+	g := life.NewGame(
+		life.WithUI(ui),
+		life.WithGenerationRate(0),
+		life.WithMaxGenerations(3),
+		life.WithGeneration(blinker),
+	)
+	ui.pause = g.Pause
+
+	addr := freeAddr(t)
+	served := make(chan error)
+	go func() { served <- g.ServeGRPC(addr) }()
+	go g.Run()
+	waitFor(t, func() bool {
+		conn, err := net.Dial("tcp", addr)
+		if err == nil {
+			conn.Close()
+		}
+		return err == nil && ui.count() == 1
+	})
+
+	c := newGRPCClient(t, addr)
+	ctx, cancel := context.WithCancel(context.Background())
+	watch := c.call(ctx, "Watch", nil)
+
+	first := decodeFields(t, readMessage(t, watch.Body))
+	if first[1].n != 0 || first[2].n != 3 || first[3].n != 3 {
+		t.Errorf("want: generation 0 of a 3x3 board, got: %+v", first)
+	}
+	// the middle row, cells 3 to 5, is alive
+	if want := []byte{0x1c, 0x00}; !bytes.Equal(first[4].bytes, want) {
+		t.Errorf("want: cells %08b, got: %08b", want, first[4].bytes)
+	}
+
+	if n := c.control(3); n != 1 {
+		t.Errorf("want: a step to generation 1, got: %v", n)
+	}
+	second := decodeFields(t, readMessage(t, watch.Body))
+	// the middle column, cells 1, 4 and 7, is alive
+	if want := []byte{0x49, 0x00}; second[1].n != 1 || !bytes.Equal(second[4].bytes, want) {
+		t.Errorf("want: generation 1 with cells %08b, got: %+v", want, second)
+	}
+
+	// cancelling the watch leaves the server serving
+	cancel()
+	watch.Body.Close()
+
+	if n := c.control(4); n != 0 {
+		t.Errorf("want: a reset to generation 0, got: %v", n)
+	}
+	c.control(2)
+
+	select {
+	case err := <-served:
+		if err != nil {
+			t.Errorf("want: no error, got: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("want: ServeGRPC to return once the game finishes")
+	}
+}
+
+func TestServeGRPCWatchToEnd(t *testing.T) {
+	blinker := newBoard(life.Dimension{X: 3, Y: 3}, [2]int{0, 1}, [2]int{1, 1}, [2]int{2, 1})
+	ui := &pausingUI{after: 1}
+	g := life.NewGame(
+		life.WithUI(ui),
+		life.WithGenerationRate(0),
+		life.WithMaxGenerations(12),
+		life.WithGeneration(blinker),
+	)
+	ui.pause = g.Pause
+
+	addr := freeAddr(t)
+	go g.ServeGRPC(addr)
+	ran := make(chan struct{})
+	go func() {
+		g.Run()
+		close(ran)
+	}()
+	waitFor(t, func() bool {
+		conn, err := net.Dial("tcp", addr)
+		if err == nil {
+			conn.Close()
+		}
+		return err == nil && ui.count() == 1
+	})
+
+	c := newGRPCClient(t, addr)
+	watch := c.call(context.Background(), "Watch", nil)
+	defer watch.Body.Close()
+	if first := decodeFields(t, readMessage(t, watch.Body)); first[1].n != 0 {
+		t.Fatalf("want: generation 0 first, got: %+v", first)
+	}
+
+	// the game runs to the end at once, well ahead of the watch
+	c.control(2)
+	<-ran
+
+	var last uint64
+	for {
+		var prefix [5]byte
+		if _, err := io.ReadFull(watch.Body, prefix[:]); err != nil {
+			break
+		}
+		msg := make([]byte, binary.BigEndian.Uint32(prefix[1:]))
+		if _, err := io.ReadFull(watch.Body, msg); err != nil {
+			t.Fatal(err)
+		}
+		n := decodeFields(t, msg)[1].n
+		if n != last+1 {
+			t.Errorf("want: generation %d, got: %d", last+1, n)
+		}
+		last = n
+	}
+
+	if last != 12 {
+		t.Errorf("want: the stream to end with generation 12, got: %d", last)
+	}
+	if status := watch.Trailer.Get("Grpc-Status"); status != "0" {
+		t.Errorf("want: status 0, got: %q", status)
+	}
+}
+
+func TestServeGRPCUnknownMethod(t *testing.T) {
+	g := life.NewGame(life.WithUI(life.NopUI{}), life.WithGenerationRate(time.Hour))
+	addr := freeAddr(t)
+	go g.ServeGRPC(addr)
+	defer g.Stop()
+	waitFor(t, func() bool {
+		conn, err := net.Dial("tcp", addr)
+		if err == nil {
+			conn.Close()
+		}
+		return err == nil
+	})
+
+	resp := newGRPCClient(t, addr).call(context.Background(), "Teleport", nil)
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	if status := resp.Trailer.Get("Grpc-Status"); status != "12" {
+		t.Errorf("want: status 12, unimplemented, got: %q", status)
+	}
+}
@@ -0,0 +1,52 @@
+package life
+
+import (
+	"io"
+	"strings"
+)
+
+// NewHalfBlockUI creates a terminal UI which draws two rows of cells on each
+// line using the half block characters '▀', '▄' and '█', doubling the vertical
+// resolution of the plain renderer. When the board has an odd height the last
+// line's lower half is drawn as dead.
+func NewHalfBlockUI(w io.Writer) UI {
+	return &halfBlockUI{TermUI: NewTerminalUI(w)}
+}
+
+type halfBlockUI struct {
+	*TermUI
+}
+
+// WriteGeneration redraws the screen with the generation
+func (h *halfBlockUI) WriteGeneration(_ int, g *Generation) {
+	h.ClearScreen()
+	h.Write(halfBlocks(g))
+}
+
+// halfBlocks renders the generation two rows to a line
+func halfBlocks(g *Generation) string {
+	d := g.dimensions
+	alive := func(x, y int) bool {
+		return y < d.Y && g.cells[x+y*d.X].Alive()
+	}
+
+	var b strings.Builder
+	for y := 0; y < d.Y; y += 2 {
+		for x := 0; x < d.X; x++ {
+			top, bottom := alive(x, y), alive(x, y+1)
+			switch {
+			case top && bottom:
+				b.WriteRune('█')
+			case top:
+				b.WriteRune('▀')
+			case bottom:
+				b.WriteRune('▄')
+			default:
+				b.WriteByte(' ')
+			}
+		}
+		b.WriteByte('\n')
+	}
+
+	return b.String()
+}
@@ -0,0 +1,26 @@
+package life_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/enocom/life"
+)
+
+func TestHalfBlockUI(t *testing.T) {
+	// o . o
+	// o o .
+	// . o .
+	g := newBoard(life.Dimension{X: 3, Y: 3},
+		[2]int{0, 0}, [2]int{2, 0}, [2]int{0, 1}, [2]int{1, 1}, [2]int{1, 2},
+	)
+
+	var buf strings.Builder
+	ui := life.NewHalfBlockUI(&buf)
+	ui.(life.GenerationUI).WriteGeneration(0, g)
+
+	want := "\033[H\033[2J" + "█▄▀\n" + " ▀ \n"
+	if got := buf.String(); got != want {
+		t.Errorf("want: %#v, got: %#v", want, got)
+	}
+}
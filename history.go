@@ -0,0 +1,78 @@
+package life
+
+import "errors"
+
+// ErrNoHistory is returned by StepBack when no earlier generation is stored
+var ErrNoHistory = errors.New("life: no history")
+
+// WithHistory keeps the last n generations of the run so StepBack can return
+// to them. By default no history is kept.
+func WithHistory(n int) GameOption {
+	return func(g *Game) {
+		g.historySize = n
+	}
+}
+
+// snapshot is a generation of a run and its number
+type snapshot struct {
+	n   int
+	gen *Generation
+}
+
+// StepBack moves the game back to the generation before the current one,
+// drawing it and returning a copy. The game is paused first, since it would
+// otherwise step forward again at once; on Resume it continues from the
+// earlier generation. Called repeatedly, StepBack walks back through the
+// generations kept by WithHistory, and returns ErrNoHistory once they are used
+// up or when no history is kept.
+//
+// Earlier generations are only ever taken from the history. A Life board
+// usually has many possible predecessors, or none, so StepBack makes no
+// attempt to compute one.
+func (g *Game) StepBack() (*Generation, error) {
+	g.Pause()
+
+	g.mu.Lock()
+	if len(g.history) == 0 {
+		g.mu.Unlock()
+		return nil, ErrNoHistory
+	}
+	s := g.history[len(g.history)-1]
+	g.history = g.history[:len(g.history)-1]
+	g.current, g.currentN, g.rewound = s.gen, s.n, &s
+	g.publish(s.n, s.gen)
+	g.mu.Unlock()
+
+	g.render(s.n, s.gen)
+
+	return s.gen.successor(append([]Cell(nil), s.gen.cells...)), nil
+}
+
+// rewind returns the generation StepBack moved the game back to, if it has
+// been moved back since the last call
+func (g *Game) rewind() (snapshot, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	s := g.rewound
+	g.rewound = nil
+	if s == nil {
+		return snapshot{}, false
+	}
+
+	return *s, true
+}
+
+// remember adds the current generation to the history before the nth
+// generation replaces it. Replacing a generation with one of the same number,
+// as when the board is resized, keeps no history. The caller holds g.mu.
+func (g *Game) remember(n int) {
+	if g.historySize <= 0 || g.current == nil || n <= g.currentN {
+		return
+	}
+
+	if len(g.history) == g.historySize {
+		g.history = append(g.history[:0], g.history[1:]...)
+	}
+	g.history = append(g.history, snapshot{n: g.currentN, gen: g.current})
+}
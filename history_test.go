@@ -0,0 +1,92 @@
+package life_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/enocom/life"
+)
+
+// pausingUI records frames and calls pause after drawing the given frame
+type pausingUI struct {
+	syncUI
+	after int
+	pause func()
+}
+
+func (p *pausingUI) Write(frame string) {
+	p.syncUI.Write(frame)
+	if p.count() == p.after {
+		p.pause()
+	}
+}
+
+func TestStepBack(t *testing.T) {
+	glider := newBoard(life.Dimension{X: 8, Y: 8},
+		[2]int{1, 0}, [2]int{2, 1}, [2]int{0, 2}, [2]int{1, 2}, [2]int{2, 2},
+	)
+	gens := []*life.Generation{glider}
+	for i := 0; i < 4; i++ {
+		gens = append(gens, life.Next(gens[i]))
+	}
+
+	ui := &pausingUI{after: 3}
+	g := life.NewGame(
+		life.WithUI(ui),
+		life.WithGenerationRate(0),
+		life.WithMaxGenerations(4),
+		life.WithGeneration(glider),
+		life.WithHistory(5),
+	)
+	ui.pause = g.Pause
+
+	done := make(chan int)
+	go func() { done <- g.Run() }()
+	waitFor(t, func() bool { return ui.count() == 3 })
+
+	back, err := g.StepBack()
+	if err != nil {
+		t.Fatalf("want: no error, got: %v", err)
+	}
+	if !equal(back.Cells(), gens[1].Cells()) {
+		t.Errorf("want: generation 1, got: %v", back)
+	}
+
+	g.Resume()
+	if n := <-done; n != 4 {
+		t.Errorf("want: 4 generations, got: %v", n)
+	}
+
+	want := []*life.Generation{gens[0], gens[1], gens[2], gens[1], gens[2], gens[3], gens[4]}
+	if len(ui.frames) != len(want) {
+		t.Fatalf("want: %v frames, got: %v", len(want), len(ui.frames))
+	}
+	for i, w := range want {
+		if ui.frames[i] != w.String() {
+			t.Errorf("frame %d: want: %#v, got: %#v", i, w.String(), ui.frames[i])
+		}
+	}
+}
+
+func TestStepBackNoHistory(t *testing.T) {
+	g := life.NewGame(
+		life.WithUI(life.NopUI{}),
+		life.WithGenerationRate(0),
+		life.WithMaxGenerations(3),
+		life.WithHistory(2),
+	)
+	g.Run()
+
+	for i := 0; i < 2; i++ {
+		if _, err := g.StepBack(); err != nil {
+			t.Fatalf("step %d: want: no error, got: %v", i, err)
+		}
+	}
+	if _, err := g.StepBack(); !errors.Is(err, life.ErrNoHistory) {
+		t.Errorf("want: %v once the history is used up, got: %v", life.ErrNoHistory, err)
+	}
+
+	if _, err := life.NewGame().StepBack(); !errors.Is(err, life.ErrNoHistory) {
+		t.Errorf("want: %v without history, got: %v", life.ErrNoHistory, err)
+	}
+}
@@ -0,0 +1,63 @@
+package life
+
+// NextIncremental produces the next generation like Next, but only
+// recomputes the cells at the (x, y) positions in dirty, copying every other
+// cell unchanged. It returns the next generation along with the dirty set for
+// the step after it: each cell which changed and its neighbors, the only cells
+// whose fate can differ next time. Passing nil recomputes every cell, so a run
+// starts with nil and passes each returned set back in. On a mostly static
+// board with a small active region this is far cheaper than a full pass.
+//
+// Positions in dirty which are not on the board are ignored. Like Next,
+// NextIncremental panics if the generation is malformed.
+func NextIncremental(g *Generation, dirty map[[2]int]struct{}) (*Generation, map[[2]int]struct{}) {
+	g.mustValidate()
+	g = g.unmasked()
+
+	d := g.dimensions
+	cells := append([]Cell(nil), g.cells...)
+	next := make(map[[2]int]struct{})
+
+	step := func(idx int) {
+		nextCell := NewDeadCell()
+		if !g.masked(idx) {
+			nextCell = generate(idx, g.cells[idx], g)
+		}
+		if nextCell != g.cells[idx] {
+			cells[idx] = nextCell
+			g.markNeighborhood(next, idx%d.X, idx/d.X)
+		}
+	}
+
+	if dirty == nil {
+		for idx := range g.cells {
+			step(idx)
+		}
+	} else {
+		for p := range dirty {
+			if p[0] >= 0 && p[1] >= 0 && p[0] < d.X && p[1] < d.Y {
+				step(p[0] + p[1]*d.X)
+			}
+		}
+	}
+
+	return g.aged(g.successor(cells)), next
+}
+
+// markNeighborhood adds (x, y) and the cells around it to set, following the
+// edges of a board which wraps. The eight surrounding cells cover the
+// neighbors of every neighborhood, on boards one cell wide as on any other.
+func (g *Generation) markNeighborhood(set map[[2]int]struct{}, x, y int) {
+	d := g.dimensions
+	for dy := -1; dy <= 1; dy++ {
+		for dx := -1; dx <= 1; dx++ {
+			nx, ny := x+dx, y+dy
+			if g.wrap {
+				nx, ny = (nx+d.X)%d.X, (ny+d.Y)%d.Y
+			}
+			if nx >= 0 && ny >= 0 && nx < d.X && ny < d.Y {
+				set[[2]int{nx, ny}] = struct{}{}
+			}
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package life_test
+
+import (
+	"testing"
+
+	"github.com/enocom/life"
+)
+
+func TestNextIncrementalMatchesNext(t *testing.T) {
+	variants := map[string][]life.Option{
+		"plain":      nil,
+		"wrap":       {life.WithWrap()},
+		"hexagonal":  {life.WithNeighborhood(life.Hexagonal)},
+		"brain":      {life.WithGenerationsRule(mustParseRule(t, "/2/3"), 3)},
+		"live edges": {life.WithBoundaryState(life.NewLiveCell())},
+	}
+
+	sizes := []life.Dimension{{X: 12, Y: 9}, {X: 1, Y: 5}, {X: 5, Y: 1}, {X: 2, Y: 7}}
+
+	for description, opts := range variants {
+		for _, d := range sizes {
+			full := life.NewGeneration(append([]life.Option{life.WithDimension(d), life.WithRandomSeed(3)}, opts...)...)
+			incremental := full
+
+			var dirty map[[2]int]struct{}
+			for n := 1; n <= 10; n++ {
+				full = life.Next(full)
+				incremental, dirty = life.NextIncremental(incremental, dirty)
+				if !equal(full.Cells(), incremental.Cells()) {
+					t.Fatalf("(%s, %v) generation %d: want: %#v, got: %#v", description, d, n, full.String(), incremental.String())
+				}
+			}
+		}
+	}
+
+	// a full column is where a cell two rows away would wrongly count
+	column := newBoard(life.Dimension{X: 1, Y: 5}, [2]int{0, 0}, [2]int{0, 1}, [2]int{0, 2}, [2]int{0, 3}, [2]int{0, 4})
+	got, _ := life.NextIncremental(column, nil)
+	if want := life.Next(column); !equal(got.Cells(), want.Cells()) {
+		t.Errorf("want: %#v, got: %#v", want.String(), got.String())
+	}
+}
+
+func TestNextIncrementalDirtySet(t *testing.T) {
+	// a blinker in a corner of a large board only ever dirties its own area
+	g := newBoard(life.Dimension{X: 64, Y: 64}, [2]int{1, 2}, [2]int{2, 2}, [2]int{3, 2})
+
+	var dirty map[[2]int]struct{}
+	for n := 0; n < 4; n++ {
+		g, dirty = life.NextIncremental(g, dirty)
+	}
+
+	if len(dirty) == 0 || len(dirty) > 25 {
+		t.Errorf("want: a dirty set around the blinker, got %v cells", len(dirty))
+	}
+	for p := range dirty {
+		if p[0] > 4 || p[1] > 4 {
+			t.Errorf("want: only cells near the blinker, got: %v", p)
+		}
+	}
+
+	still := newBoard(life.Dimension{X: 4, Y: 4}, [2]int{1, 1}, [2]int{2, 1}, [2]int{1, 2}, [2]int{2, 2})
+	if _, dirty := life.NextIncremental(still, nil); len(dirty) != 0 {
+		t.Errorf("want: nothing dirty after a still life's step, got: %v", dirty)
+	}
+}
+
+func mustParseRule(t *testing.T, s string) life.Rule {
+	t.Helper()
+
+	r, err := life.ParseRule(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
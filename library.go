@@ -0,0 +1,67 @@
+package life
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// NamedPattern is a pattern file found by LoadLibrary
+type NamedPattern struct {
+	// Name is the pattern's name from its file, or else the file name
+	// without its extension
+	Name string
+	Path string
+	// Pattern is the loaded pattern, or nil when the file could not be
+	// parsed, in which case Err says why
+	Pattern *Generation
+	Err     error
+}
+
+// LoadLibrary finds every .rle and .cells file in dir and its subdirectories,
+// in lexical order of their paths, and loads each one. A file which cannot be
+// read or parsed is still listed, with its error, rather than ending the scan.
+// The error is only non-nil when dir itself cannot be walked.
+func LoadLibrary(dir string) ([]NamedPattern, error) {
+	var patterns []NamedPattern
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		ext := strings.ToLower(filepath.Ext(path))
+		if d.IsDir() || (ext != ".rle" && ext != ".cells") {
+			return nil
+		}
+
+		p := NamedPattern{
+			Name: strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)),
+			Path: path,
+		}
+		p.Pattern, p.Err = loadPatternFile(path, ext)
+		if p.Pattern != nil && p.Pattern.meta.Name != "" {
+			p.Name = p.Pattern.meta.Name
+		}
+		patterns = append(patterns, p)
+
+		return nil
+	})
+
+	return patterns, err
+}
+
+// loadPatternFile reads the pattern at path in the format given by its
+// lowercase extension
+func loadPatternFile(path, ext string) (*Generation, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	if ext == ".rle" {
+		return LoadRLE(f)
+	}
+
+	return LoadPlaintext(f)
+}
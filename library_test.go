@@ -0,0 +1,60 @@
+package life_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/enocom/life"
+)
+
+func TestLoadLibrary(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"glider.rle":          "#N Glider\nx = 3, y = 3\nbo$2bo$3o!\n",
+		"broken.rle":          "x = 3\n",
+		"notes.txt":           "not a pattern",
+		"still/block.cells":   "OO\nOO\n",
+		"still/beehive.CELLS": "!Name: Beehive\n.OO.\nO..O\n.OO.\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	patterns, err := life.LoadLibrary(dir)
+	if err != nil {
+		t.Fatalf("want: no error, got: %v", err)
+	}
+
+	want := []struct {
+		name   string
+		errors bool
+	}{
+		{name: "broken", errors: true},
+		{name: "Glider"},
+		{name: "Beehive"},
+		{name: "block"},
+	}
+	if len(patterns) != len(want) {
+		t.Fatalf("want: %v patterns, got: %+v", len(want), patterns)
+	}
+	for i, w := range want {
+		p := patterns[i]
+		if p.Name != w.name || (p.Err != nil) != w.errors || (p.Pattern == nil) != w.errors {
+			t.Errorf("pattern %d: want: %v (errors = %v), got: %+v", i, w.name, w.errors, p)
+		}
+	}
+	if got := patterns[3].Pattern.Population(); got != 4 {
+		t.Errorf("want: a block of 4 cells, got: %v", got)
+	}
+
+	if _, err := life.LoadLibrary(filepath.Join(dir, "missing")); err == nil {
+		t.Error("want: an error for a missing directory")
+	}
+}
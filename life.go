@@ -21,10 +21,15 @@
 package life
 
 import (
+	"bytes"
 	"fmt"
+	"hash/fnv"
 	"io"
+	"log"
 	"math/rand"
 	"os"
+	"sync"
+	"text/template"
 	"time"
 )
 
@@ -41,6 +46,7 @@ func NewDeadCell() Cell {
 // Cell represents a single living entity
 type Cell struct {
 	alive bool
+	dying uint8 // generations spent dying under a Generations rule
 }
 
 // Alive returns the state of the cell
@@ -48,6 +54,32 @@ func (c Cell) Alive() bool {
 	return c.alive
 }
 
+// State returns the state of the cell as numbered by Generations rules: 0 for
+// a dead cell, 1 for a live one, and 2 and above for a cell which has begun
+// dying. Dying cells are not alive and are drawn as dead ones.
+func (c Cell) State() int {
+	switch {
+	case c.alive:
+		return 1
+	case c.dying > 0:
+		return int(c.dying) + 1
+	default:
+		return 0
+	}
+}
+
+// cellInState returns a cell in the given state, numbered as by State
+func cellInState(state int) Cell {
+	switch {
+	case state == 1:
+		return Cell{alive: true}
+	case state > 1:
+		return Cell{dying: uint8(state - 1)}
+	default:
+		return Cell{}
+	}
+}
+
 // String returns a string representation of a Cell
 func (c Cell) String() string {
 	if c.Alive() {
@@ -70,10 +102,6 @@ func (d Dimension) LeftEdge(idx int) bool {
 
 // RightEdge returns whether an index is on the right edge of the board
 func (d Dimension) RightEdge(idx int) bool {
-	if idx == 0 {
-		return false
-	}
-
 	return idx%d.X == d.X-1
 }
 
@@ -87,6 +115,15 @@ type CellGenerator interface {
 	Generate() Cell
 }
 
+// PositionalCellGenerator is a CellGenerator which can generate the cell at a
+// given position, for seeds which depend on coordinates such as a
+// checkerboard or a gradient. NewGeneration calls GenerateAt in place of
+// Generate for such a generator.
+type PositionalCellGenerator interface {
+	CellGenerator
+	GenerateAt(x, y int) Cell
+}
+
 // NewFixedCellGenerator is used when users want to configure a deterministic
 // collection of cells in a generation
 func NewFixedCellGenerator(c []Cell) CellGenerator {
@@ -119,6 +156,14 @@ func NewRandomCellGenerator() CellGenerator {
 	}
 }
 
+// NewSeededCellGenerator creates a CellGenerator which returns living and dead
+// cells randomly, producing the same sequence of cells for the same seed
+func NewSeededCellGenerator(seed int64) CellGenerator {
+	return &randomCellGenerator{
+		r: rand.New(rand.NewSource(seed)),
+	}
+}
+
 type randomCellGenerator struct {
 	r *rand.Rand
 }
@@ -159,10 +204,98 @@ func WithRandomCells() Option {
 	}
 }
 
+// WithCellGenerator configures a generation to be seeded with the cells
+// returned by gen, in index order, or by position for a
+// PositionalCellGenerator
+func WithCellGenerator(gen CellGenerator) Option {
+	return func(g *Generation) {
+		g.generator = gen
+	}
+}
+
+// WithRandomSeed configures a generation to be seeded with living and dead
+// cells randomly, reproducibly for a given seed
+func WithRandomSeed(seed int64) Option {
+	return func(g *Generation) {
+		g.generator = NewSeededCellGenerator(seed)
+	}
+}
+
+// WithSeedString configures a generation to be seeded randomly from a word or
+// phrase, so a memorable string such as "galaxy" always reproduces the same
+// board. It is the same as WithRandomSeed with the string's 64 bit FNV-1a
+// hash as the seed.
+func WithSeedString(s string) Option {
+	return WithRandomSeed(seedFromString(s))
+}
+
+// seedFromString hashes s into a seed for WithRandomSeed
+func seedFromString(s string) int64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(s))
+
+	return int64(h.Sum64())
+}
+
+// Neighborhood determines which cells count as the neighbors of a cell
+type Neighborhood int
+
+const (
+	// Moore counts the eight cells surrounding a cell. It is the default.
+	Moore Neighborhood = iota
+	// Hexagonal treats the board as a grid of hexagons in which odd rows are
+	// offset half a cell to the right, giving each cell six neighbors: two in
+	// its own row and two in each of the rows above and below.
+	Hexagonal
+	// VonNeumann counts only the four cells orthogonally adjacent to a cell,
+	// ignoring diagonals
+	VonNeumann
+)
+
+// WithNeighborhood configures which cells count as neighbors when producing
+// the next generation
+func WithNeighborhood(n Neighborhood) Option {
+	return func(g *Generation) {
+		g.neighborhood = n
+	}
+}
+
+// WithBoundaryState configures the state of the cells imagined beyond the edges
+// of the board. By default they are dead, so cells on the edge simply have
+// fewer neighbors; a live boundary counts every off-board neighbor as alive.
+func WithBoundaryState(c Cell) Option {
+	return func(g *Generation) {
+		g.boundary = c
+	}
+}
+
+// WithWrap joins the opposite edges of the board, so it behaves as a torus: a
+// cell on the left edge neighbors the cells on the right edge and a cell on
+// the top edge those on the bottom edge. The boundary state is not used on a
+// wrapping board.
+func WithWrap() Option {
+	return func(g *Generation) {
+		g.wrap = true
+	}
+}
+
+// WithMask limits the board to the cells for which include returns true,
+// giving it any shape, such as a disc or a ring. Cells outside the mask are
+// always dead, so they are never counted as live neighbors, while cells beyond
+// the edges of the board still follow the boundary state. A cell outside the
+// mask made alive with Set, Stamp or the like is ignored by its neighbors and
+// dead again in the next generation. By default every cell is included.
+func WithMask(include func(x, y int) bool) Option {
+	return func(g *Generation) {
+		g.mask = include
+	}
+}
+
 // NewGeneration returns a single generation of cells
 func NewGeneration(opts ...Option) *Generation {
 	g := &Generation{
 		dimensions: Dimension{X: 3, Y: 3},
+		rule:       Conway,
 		generator:  NewRandomCellGenerator(),
 	}
 
@@ -170,11 +303,25 @@ func NewGeneration(opts ...Option) *Generation {
 		o(g)
 	}
 
+	positional, _ := g.generator.(PositionalCellGenerator)
+
 	var cells []Cell
 	for i := 0; i < g.dimensions.X*g.dimensions.Y; i++ {
-		cells = append(cells, g.generator.Generate())
+		var c Cell
+		if positional != nil {
+			c = positional.GenerateAt(i%g.dimensions.X, i/g.dimensions.X)
+		} else {
+			c = g.generator.Generate()
+		}
+		if g.masked(i) {
+			c = NewDeadCell()
+		}
+		cells = append(cells, c)
 	}
 	g.cells = cells
+	if g.trackAges {
+		g.ages = startAges(cells)
+	}
 
 	return g
 }
@@ -182,9 +329,17 @@ func NewGeneration(opts ...Option) *Generation {
 // Generation represents a collective state of living
 // and dead cells
 type Generation struct {
-	dimensions Dimension
-	generator  CellGenerator
-	cells      []Cell
+	dimensions   Dimension
+	neighborhood Neighborhood
+	boundary     Cell
+	wrap         bool
+	mask         func(x, y int) bool
+	rule         Rule
+	meta         PatternMeta
+	generator    CellGenerator
+	cells        []Cell
+	trackAges    bool
+	ages         []int // how long each cell has been alive, with trackAges
 }
 
 // Cells returns the generation's cells
@@ -192,68 +347,225 @@ func (g *Generation) Cells() []Cell {
 	return g.cells
 }
 
-// String returns a representation of Generation
-func (g *Generation) String() string {
-	display := ""
-	for row := 0; row < g.dimensions.Y; row++ {
-		for column := 0; column < g.dimensions.X; column++ {
-			display += fmt.Sprintf("%v", g.cells[column+row*g.dimensions.X])
-
-			if column%g.dimensions.X == g.dimensions.X-1 {
-				display += "\n"
-			} else {
-				display += " "
-			}
+// Population returns the number of live cells
+func (g *Generation) Population() int {
+	count := 0
+	for _, c := range g.cells {
+		if c.Alive() {
+			count++
 		}
 	}
-	return display
+
+	return count
+}
+
+// IsEmpty reports whether the generation has no live cells
+func (g *Generation) IsEmpty() bool {
+	for _, c := range g.cells {
+		if c.Alive() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Dimension returns the size of the generation's board
+func (g *Generation) Dimension() Dimension {
+	return g.dimensions
+}
+
+// successor returns a generation holding cells and otherwise configured like g
+func (g *Generation) successor(cells []Cell) *Generation {
+	next := *g
+	next.generator = nil
+	next.cells = cells
+	next.ages = nil
+
+	return &next
+}
+
+// String returns a representation of Generation. Hexagonal boards offset odd
+// rows by half a cell.
+func (g *Generation) String() string {
+	return g.Render()
 }
 
 // Next produces the next generation with some cells living
-// and some cells dying
+// and some cells dying. Next panics if the generation is malformed; use
+// NextErr to handle that case.
 func Next(g1 *Generation) *Generation {
+	g2, err := NextErr(g1)
+	if err != nil {
+		panic(err)
+	}
+
+	return g2
+}
+
+// NextErr is like Next, but returns an error when the number of cells in the
+// generation does not match its dimensions rather than failing partway
+// through.
+func NextErr(g1 *Generation) (*Generation, error) {
+	if err := g1.validate(); err != nil {
+		return nil, err
+	}
+	g1 = g1.unmasked()
+
+	// nothing can be born on an empty board unless the boundary is alive or
+	// the rule gives birth with no neighbors, and nothing is left to decay
+	// under a two state rule
+	if !g1.boundary.Alive() && !g1.rule.birth[0] && g1.IsEmpty() && g1.rule.States() == 2 {
+		return g1.aged(g1.successor(make([]Cell, len(g1.cells)))), nil
+	}
+
+	if g2, ok := nextFlat(g1); ok {
+		return g1.aged(g2), nil
+	}
+
+	return g1.aged(nextWith(g1, g1.rule.next)), nil
+}
+
+// NextWith produces the next generation by passing each cell and its number
+// of live neighbors to fn, which returns the cell's next state. It runs any
+// outer totalistic automaton on the generation's board and neighborhood,
+// ignoring its rule; Next is the same as NextWith the generation's rule. Like
+// Next, NextWith panics if the generation is malformed.
+func NextWith(g1 *Generation, fn func(c Cell, liveNeighbors int) Cell) *Generation {
+	g1.mustValidate()
+	g1 = g1.unmasked()
+
+	return g1.aged(nextWith(g1, fn))
+}
+
+// nextWith applies fn to every cell of a valid generation
+func nextWith(g1 *Generation, fn func(c Cell, liveNeighbors int) Cell) *Generation {
 	g1Cells := g1.cells
 	var g2Cells []Cell
 	for i, cell := range g1Cells {
-		nextCell := generate(i, cell, g1Cells, g1.dimensions)
+		nextCell := NewDeadCell()
+		if !g1.masked(i) {
+			nextCell = fn(cell, neighbors(i, g1))
+		}
 		g2Cells = append(g2Cells, nextCell)
 	}
-	return &Generation{
-		dimensions: g1.dimensions,
-		cells:      g2Cells,
+	return g1.successor(g2Cells)
+}
+
+// NeighborCounts returns the number of live neighbors of every cell, the
+// counts Next passes to the rule, in the same order as Cells. The counts
+// follow the generation's neighborhood, wrapping and boundary; cells outside
+// its mask have no neighbors. Like Next, NeighborCounts panics if the
+// generation is malformed.
+func (g *Generation) NeighborCounts() []int {
+	g.mustValidate()
+	g = g.unmasked()
+
+	counts := make([]int, len(g.cells))
+	for i := range g.cells {
+		if !g.masked(i) {
+			counts[i] = neighbors(i, g)
+		}
 	}
+
+	return counts
 }
 
-func generate(idx int, c Cell, cells []Cell, d Dimension) Cell {
-	liveNeighbors := leftCell(idx, cells, d.X) +
-		rightCell(idx, cells, d.X) +
-		aboveCell(idx, cells, d) +
-		belowCell(idx, cells, d) +
-		aboveDiagonalCells(idx, cells, d) +
-		belowDiagonalCells(idx, cells, d)
+// masked reports whether the cell at idx lies outside the generation's mask
+func (g *Generation) masked(idx int) bool {
+	return g.mask != nil && !g.mask(idx%g.dimensions.X, idx/g.dimensions.X)
+}
 
-	if !c.Alive() && liveNeighbors == 3 {
-		return NewLiveCell()
+// unmasked returns the generation with any live cells outside its mask, as
+// Set, Stamp or WithCells can leave there, cleared so they are never counted
+// as neighbors. It returns g itself when there are none.
+func (g *Generation) unmasked() *Generation {
+	if g.mask == nil {
+		return g
 	}
 
-	if !c.Alive() {
-		return NewDeadCell()
+	var cells []Cell
+	for i, c := range g.cells {
+		if c == (Cell{}) || !g.masked(i) {
+			continue
+		}
+		if cells == nil {
+			cells = append([]Cell(nil), g.cells...)
+		}
+		cells[i] = Cell{}
+	}
+	if cells == nil {
+		return g
 	}
 
-	switch liveNeighbors {
-	case 0, 1:
-		return NewDeadCell()
-	case 2, 3:
-		return NewLiveCell()
+	clean := *g
+	clean.cells = cells
+
+	return &clean
+}
+
+// validate checks that the generation holds one cell per board position
+func (g *Generation) validate() error {
+	d := g.dimensions
+	if d.X < 0 || d.Y < 0 {
+		return fmt.Errorf("life: invalid dimension %dx%d", d.X, d.Y)
+	}
+
+	if len(g.cells) != d.X*d.Y {
+		return fmt.Errorf("life: generation has %d cells, want %d for a %dx%d board",
+			len(g.cells), d.X*d.Y, d.X, d.Y)
+	}
+
+	return nil
+}
+
+// mustValidate panics with the error NextErr would return for a malformed
+// generation, for the steppers which, like Next, have no error to return
+func (g *Generation) mustValidate() {
+	if err := g.validate(); err != nil {
+		panic(err)
+	}
+}
+
+func generate(idx int, c Cell, g *Generation) Cell {
+	return g.rule.next(c, neighbors(idx, g))
+}
+
+// neighbors counts the live neighbors of the cell at idx, including any
+// positions beyond the edge of the board which the boundary makes alive
+func neighbors(idx int, g *Generation) int {
+	if g.wrap {
+		return wrappedNeighbors(idx, g)
+	}
+
+	cells, d := g.cells, g.dimensions
+
+	edge := 0
+	if g.boundary.Alive() {
+		edge = 1
+	}
+
+	orthogonal := leftCell(idx, cells, d.X, edge) +
+		rightCell(idx, cells, d.X, edge) +
+		aboveCell(idx, cells, d, edge) +
+		belowCell(idx, cells, d, edge)
+
+	switch g.neighborhood {
+	case Hexagonal:
+		return hexagonalCells(idx, cells, d, edge)
+	case VonNeumann:
+		return orthogonal
 	default:
-		return NewDeadCell()
+		return orthogonal +
+			aboveDiagonalCells(idx, cells, d, edge) +
+			belowDiagonalCells(idx, cells, d, edge)
 	}
 }
 
 // checkLeft determines if the left cell is alive
-func leftCell(idx int, cells []Cell, x int) int {
+func leftCell(idx int, cells []Cell, x int, edge int) int {
 	if idx%x == 0 {
-		return 0
+		return edge
 	}
 
 	if cells[idx-1].Alive() {
@@ -264,9 +576,9 @@ func leftCell(idx int, cells []Cell, x int) int {
 }
 
 // checkRight determines if the right cellis alive
-func rightCell(idx int, cells []Cell, x int) int {
+func rightCell(idx int, cells []Cell, x int, edge int) int {
 	if idx%x == x-1 {
-		return 0
+		return edge
 	}
 
 	if cells[idx+1].Alive() {
@@ -276,10 +588,10 @@ func rightCell(idx int, cells []Cell, x int) int {
 	return 0
 }
 
-func aboveCell(idx int, cells []Cell, d Dimension) int {
+func aboveCell(idx int, cells []Cell, d Dimension, edge int) int {
 	// we're in the first row; there is no above
 	if idx < d.X {
-		return 0
+		return edge
 	}
 
 	if cells[idx-d.X].Alive() {
@@ -289,10 +601,10 @@ func aboveCell(idx int, cells []Cell, d Dimension) int {
 	return 0
 }
 
-func belowCell(idx int, cells []Cell, d Dimension) int {
+func belowCell(idx int, cells []Cell, d Dimension, edge int) int {
 	// we're in the last row; there is no below
 	if idx >= d.LastRowFirstIndex() {
-		return 0
+		return edge
 	}
 
 	if cells[idx+d.X].Alive() {
@@ -302,45 +614,120 @@ func belowCell(idx int, cells []Cell, d Dimension) int {
 	return 0
 }
 
-func aboveDiagonalCells(idx int, cells []Cell, d Dimension) int {
+func aboveDiagonalCells(idx int, cells []Cell, d Dimension, edge int) int {
 	count := 0
 
 	// we're in the first row; there is no above
 	if idx < d.X {
-		return count
+		return 2 * edge
 	}
 
 	// diagonal left
-	if !d.LeftEdge(idx) && cells[idx-d.X-1].Alive() {
+	if d.LeftEdge(idx) {
+		count += edge
+	} else if cells[idx-d.X-1].Alive() {
 		count++
 	}
 
 	// diagonal right
-	if !d.RightEdge(idx) && cells[idx-d.X+1].Alive() {
+	if d.RightEdge(idx) {
+		count += edge
+	} else if cells[idx-d.X+1].Alive() {
 		count++
 	}
 
 	return count
 }
 
-func belowDiagonalCells(idx int, cells []Cell, d Dimension) int {
+func belowDiagonalCells(idx int, cells []Cell, d Dimension, edge int) int {
 	count := 0
 	// we're in the last row; there is no below
 	lastRowStartIdx := (d.Y * d.X) - d.X
 	if idx >= lastRowStartIdx {
-		return 0
+		return 2 * edge
 	}
 
 	// diagonal left
-	if !d.LeftEdge(idx) && cells[idx+d.X-1].Alive() {
+	if d.LeftEdge(idx) {
+		count += edge
+	} else if cells[idx+d.X-1].Alive() {
 		count++
 	}
 
 	// diagonal right
-	if !d.RightEdge(idx) && cells[idx+d.X+1].Alive() {
+	if d.RightEdge(idx) {
+		count += edge
+	} else if cells[idx+d.X+1].Alive() {
+		count++
+	}
+
+	return count
+}
+
+// hexagonalCells counts the six neighbors of a cell on a board whose odd rows
+// are offset half a cell to the right. Besides the cells directly above and
+// below, even rows reach diagonally left and odd rows diagonally right.
+func hexagonalCells(idx int, cells []Cell, d Dimension, edge int) int {
+	count := leftCell(idx, cells, d.X, edge) +
+		rightCell(idx, cells, d.X, edge) +
+		aboveCell(idx, cells, d, edge) +
+		belowCell(idx, cells, d, edge)
+
+	offset := -1
+	if (idx/d.X)%2 == 1 {
+		offset = 1
+	}
+
+	// the diagonal neighbors would be off the board
+	column := idx%d.X + offset
+	if column < 0 || column >= d.X {
+		return count + 2*edge
+	}
+
+	if idx < d.X {
+		count += edge
+	} else if cells[idx-d.X+offset].Alive() {
 		count++
 	}
 
+	if idx >= d.LastRowFirstIndex() {
+		count += edge
+	} else if cells[idx+d.X+offset].Alive() {
+		count++
+	}
+
+	return count
+}
+
+// wrappedNeighbors counts the live neighbors of the cell at idx on a board
+// whose opposite edges are joined
+func wrappedNeighbors(idx int, g *Generation) int {
+	d := g.dimensions
+	x, y := idx%d.X, idx/d.X
+
+	var offsets [][2]int
+	switch g.neighborhood {
+	case Hexagonal:
+		diagonal := -1
+		if y%2 == 1 {
+			diagonal = 1
+		}
+		offsets = [][2]int{{-1, 0}, {1, 0}, {0, -1}, {0, 1}, {diagonal, -1}, {diagonal, 1}}
+	case VonNeumann:
+		offsets = [][2]int{{-1, 0}, {1, 0}, {0, -1}, {0, 1}}
+	default:
+		offsets = [][2]int{{-1, -1}, {0, -1}, {1, -1}, {-1, 0}, {1, 0}, {-1, 1}, {0, 1}, {1, 1}}
+	}
+
+	count := 0
+	for _, o := range offsets {
+		nx := (x + o[0] + d.X) % d.X
+		ny := (y + o[1] + d.Y) % d.Y
+		if g.cells[nx+ny*d.X].Alive() {
+			count++
+		}
+	}
+
 	return count
 }
 
@@ -351,19 +738,50 @@ func NewTerminalUI(w io.Writer) *TermUI {
 	}
 }
 
-// TermUI represents a UI runs within a Bash shell
+// TermUI represents a UI runs within a Bash shell. Each frame, from the
+// escape codes clearing the screen to the end of the board, reaches the
+// terminal in a single write, which avoids visible tearing on slow terminals
+// and over SSH.
 type TermUI struct {
-	w io.Writer
+	w   io.Writer
+	buf bytes.Buffer // the frame being composed
 }
 
-// ClearScreen provides a means to simulate animation between generations
+// ClearScreen provides a means to simulate animation between generations. The
+// screen is cleared when the next frame is written.
 func (t *TermUI) ClearScreen() {
-	_, _ = t.w.Write([]byte("\033[H\033[2J"))
+	t.buf.WriteString("\033[H\033[2J")
 }
 
 // Write prints the frame to the screen
 func (t *TermUI) Write(frame string) {
-	_, _ = t.w.Write([]byte(frame))
+	t.buf.WriteString(frame)
+	_, _ = t.w.Write(t.buf.Bytes())
+	t.buf.Reset()
+}
+
+// NewPlainUI creates a UI which writes frames to w one after another without
+// terminal escape codes, separating them with a line naming the frame, e.g.
+// "--- frame 3 ---". Its output suits logs and golden files.
+func NewPlainUI(w io.Writer) UI {
+	return &plainUI{w: w}
+}
+
+// plainUI writes frames in sequence rather than redrawing the screen
+type plainUI struct {
+	w     io.Writer
+	frame int
+}
+
+// ClearScreen starts a new frame with a separator line
+func (p *plainUI) ClearScreen() {
+	fmt.Fprintf(p.w, "--- frame %d ---\n", p.frame)
+	p.frame++
+}
+
+// Write prints the frame
+func (p *plainUI) Write(frame string) {
+	_, _ = io.WriteString(p.w, frame)
 }
 
 // UI represents the interface all implementors must honor
@@ -372,6 +790,24 @@ type UI interface {
 	Write(string)
 }
 
+// GenerationUI is a UI which draws each generation itself rather than the
+// text frame rendered from it. The game passes such a UI the generation and
+// its number in place of calling ClearScreen and Write.
+type GenerationUI interface {
+	UI
+	WriteGeneration(n int, g *Generation)
+}
+
+// NopUI is a UI which discards every frame. Use it when only the state of the
+// game matters and rendering would dominate the run time.
+type NopUI struct{}
+
+// ClearScreen does nothing
+func (NopUI) ClearScreen() {}
+
+// Write discards the frame
+func (NopUI) Write(string) {}
+
 // GameOption provides a means to configure optional parameters
 type GameOption func(*Game)
 
@@ -383,26 +819,161 @@ func WithBoardSize(size int) GameOption {
 }
 
 // WithGenerationRate configures the speed by which one generation gives way to
-// another
+// another. A rate of zero or less produces generations as fast as possible.
+// Generations keep to a fixed schedule: when the game falls behind it still
+// produces one generation per scheduled frame but skips drawing those which
+// are already late.
 func WithGenerationRate(rate time.Duration) GameOption {
 	return func(g *Game) {
 		g.rate = rate
 	}
 }
 
-// WithUI configures the UI used by the Game
+// WithUI configures the UI used by the Game. A nil UI disables rendering
+// entirely, as with NopUI.
 func WithUI(ui UI) GameOption {
 	return func(g *Game) {
+		if ui == nil {
+			ui = NopUI{}
+		}
 		g.ui = ui
 	}
 }
 
+// WithGeneration starts the game from gen rather than a random board of the
+// configured size
+func WithGeneration(gen *Generation) GameOption {
+	return func(g *Game) {
+		g.seed = gen
+	}
+}
+
+// WithGenerationOptions configures the random board a game starts from, e.g.
+// with WithNeighborhood. It has no effect on a board passed to WithGeneration,
+// which is already configured.
+func WithGenerationOptions(opts ...Option) GameOption {
+	return func(g *Game) {
+		g.generationOpts = append(g.generationOpts, opts...)
+	}
+}
+
+// WithRenderOptions configures how each generation is drawn, e.g. with
+// WithAxes
+func WithRenderOptions(opts ...RenderOption) GameOption {
+	return func(g *Game) {
+		g.renderOpts = append(g.renderOpts, opts...)
+	}
+}
+
+// WithSignalControl lets other processes pause the game by sending it SIGUSR1
+// and resume it with SIGUSR2, which is useful when running without an
+// interactive terminal. It has no effect on systems without these signals.
+func WithSignalControl() GameOption {
+	return func(g *Game) {
+		g.signalControl = true
+	}
+}
+
+// WithFitTerminal sizes the board to fill the terminal attached to standard
+// output instead of using the configured board size. On Unix systems the board
+// is resized to follow the terminal whenever it changes size, preserving the
+// live cells which still fit.
+func WithFitTerminal() GameOption {
+	return func(g *Game) {
+		g.fitTerminal = true
+	}
+}
+
+// WithMaxGenerations stops a game after n generations have followed the
+// initial one. The default of zero runs forever.
+func WithMaxGenerations(n int) GameOption {
+	return func(g *Game) {
+		g.maxGenerations = n
+	}
+}
+
+// WithMaxDuration stops a game once d has elapsed since it started, however
+// many generations were produced. When combined with WithMaxGenerations,
+// whichever limit is reached first stops the game. The default of zero runs
+// forever.
+func WithMaxDuration(d time.Duration) GameOption {
+	return func(g *Game) {
+		g.maxDuration = d
+	}
+}
+
+// WithMaxPopulation stops a game once more than n cells are alive, guarding
+// against patterns which grow without bound on large boards. StopReason reports
+// MaxPopulationExceeded when the cap stops the game. The default of zero
+// disables the cap.
+func WithMaxPopulation(n int) GameOption {
+	return func(g *Game) {
+		g.maxPopulation = n
+	}
+}
+
+// WithLogger configures where the game logs warnings, such as a generation
+// taking longer to compute and render than the generation rate allows, or a
+// recorder given to WithUI failing to write a frame. By default nothing is
+// logged.
+func WithLogger(l *log.Logger) GameOption {
+	return func(g *Game) {
+		g.logger = l
+	}
+}
+
+// WithNotify calls fn with an Event whenever the game goes extinct, becomes
+// stable or enters a cycle. Each kind of event is reported once per run,
+// except Quiescent, which is reported each time WithAutoPauseOnQuiescence
+// pauses the game. The callback runs on the game's loop, so it must return
+// quickly and hand any slow work to another goroutine. See NewBellNotifier for
+// a callback which rings the terminal bell.
+func WithNotify(fn func(Event)) GameOption {
+	return func(g *Game) {
+		g.notify = fn
+	}
+}
+
+// WithProgress writes a progress line to w as the game runs, e.g.
+// "generation 50/200 (25%)" for a game bounded by WithMaxGenerations. Updates
+// are throttled to a few per second, and the final generation is always
+// reported. By default no progress is written.
+func WithProgress(w io.Writer) GameOption {
+	return func(g *Game) {
+		g.progress = newProgress(w)
+	}
+}
+
+// WithPopulationSparkline draws a header above the board giving the
+// population and a sparkline of the last n populations, e.g.
+// "population 12 ▁▃▅█▆", scaled between the smallest and largest of them so
+// growth, decline and oscillation stand out.
+func WithPopulationSparkline(n int) GameOption {
+	return func(g *Game) {
+		g.sparkline = newSparkline(n)
+	}
+}
+
+// WithAutoPauseOnQuiescence pauses the game when fewer than threshold cells
+// change from one generation to the next, so the moment a chaotic board
+// settles is not missed. The game pauses each time activity falls below the
+// threshold, not on every quiet generation, and stays paused until Resume is
+// called. A callback given to WithNotify is told of each pause with a
+// Quiescent event.
+func WithAutoPauseOnQuiescence(threshold int) GameOption {
+	return func(g *Game) {
+		g.quietThreshold = threshold
+	}
+}
+
 // NewGame creates an unstarted game
 func NewGame(opts ...GameOption) *Game {
 	g := &Game{
 		ui:        NewTerminalUI(os.Stdout),
 		dimension: Dimension{X: 10, Y: 10},
 		rate:      time.Second,
+		stop:      make(chan struct{}),
+		finished:  make(chan struct{}),
 	}
 
 	for _, o := range opts {
@@ -414,20 +985,521 @@ func NewGame(opts ...GameOption) *Game {
 
 // Game represents a single run of Conway's Game of Life
 type Game struct {
-	ui        UI
-	dimension Dimension
-	rate      time.Duration
+	ui             UI
+	dimension      Dimension
+	rate           time.Duration
+	maxGenerations int
+	maxDuration    time.Duration
+	maxPopulation  int
+	fitTerminal    bool
+	seed           *Generation
+	generationOpts []Option
+	renderOpts     []RenderOption
+	progress       *progress
+	sparkline      *sparkline
+	notify         func(Event)
+	quietThreshold int
+	autoRestart    bool
+	restartDelay   time.Duration
+	quiet          bool // fewer than quietThreshold cells changed last step
+	logger         *log.Logger
+	signalControl  bool
+	controlPipe    string
+	tracePath      string
+	metrics        gameMetrics
+	stop           chan struct{}
+	stopOnce       sync.Once
+	finished       chan struct{} // closed when Run returns
+	finishOnce     sync.Once
+
+	frameTemplate *template.Template
+
+	drawing sync.Mutex // held while rendering a frame
+	started time.Time  // when Run began, for frame templates
+
+	mu       sync.Mutex
+	resumed  chan struct{} // non-nil while paused, closed on resume
+	steps    int           // generations Step has asked for while paused
+	speed    float64       // set by SetSpeed until the game applies it
+	first    *Generation   // the generation the game started from
+	reason   StopReason
+	current  *Generation
+	currentN int // the number of the current generation
+	restarts int
+
+	historySize int
+	history     []snapshot
+	rewound     *snapshot // set by StepBack until the game continues from it
+
+	trackFootprint bool
+	footprint      *Generation
+
+	watchers map[chan snapshot]struct{} // see subscribe
+}
+
+// StopReason describes why a game stopped running
+type StopReason int
+
+const (
+	// Running is reported for a game which has not stopped
+	Running StopReason = iota
+	// Stopped is reported for a game ended by Stop
+	Stopped
+	// MaxGenerationsReached is reported for a game ended by WithMaxGenerations
+	MaxGenerationsReached
+	// MaxDurationReached is reported for a game ended by WithMaxDuration
+	MaxDurationReached
+	// MaxPopulationExceeded is reported for a game ended by WithMaxPopulation
+	MaxPopulationExceeded
+)
+
+func (r StopReason) String() string {
+	switch r {
+	case Stopped:
+		return "stopped"
+	case MaxGenerationsReached:
+		return "maximum generations reached"
+	case MaxDurationReached:
+		return "maximum duration reached"
+	case MaxPopulationExceeded:
+		return "maximum population exceeded"
+	default:
+		return "running"
+	}
 }
 
 // Start begins the game
 func (g *Game) Start() {
-	currentGen := NewGeneration(WithDimension(g.dimension))
-	g.ui.ClearScreen()
-	g.ui.Write(currentGen.String())
+	g.Run()
+}
+
+// Run plays the game until it is stopped or a configured limit is reached and
+// returns the number of generations which followed the initial one. Without a
+// limit Run only returns once Stop is called. StopReason reports why the game
+// stopped.
+func (g *Game) Run() int {
+	defer g.finishOnce.Do(func() { close(g.finished) })
+
+	start := time.Now()
+	g.drawing.Lock()
+	g.started = start
+	g.drawing.Unlock()
+
+	if ui, ok := g.ui.(logReceiver); ok {
+		ui.setLogger(g.logger)
+	}
+	currentGen := g.initial()
+
+	if g.signalControl {
+		defer g.handlePauseSignals()()
+	}
+	if g.controlPipe != "" {
+		defer g.listenForControl()()
+	}
+
+	var resize <-chan os.Signal
+	if g.fitTerminal {
+		var stop func()
+		resize, stop = notifyResize()
+		defer stop()
+		g.dimension = fitTerminal(g.dimension)
+		currentGen = currentGen.Resize(g.dimension)
+	}
+
+	trace := g.openTrace()
+	defer func() {
+		if err := trace.close(); err != nil {
+			g.logf("life: trace file: %v", err)
+		}
+	}()
+
+	var detect *detector
+	if g.notify != nil || g.autoRestart || g.metrics != nil {
+		detect = newDetector()
+	}
 
-	for range time.Tick(g.rate) {
+	g.mu.Lock()
+	g.first = currentGen
+	g.mu.Unlock()
+	g.setCurrent(0, currentGen)
+	g.sparkline.record(currentGen.Population())
+	g.render(0, currentGen)
+	ended := g.observe(detect, currentGen, 0)
+
+	frames := newSchedule(g.rate)
+	generations := 0
+	// stale is set while the board on screen is behind currentGen
+	stale := false
+	for !g.done(generations, time.Since(start), currentGen) {
+		select {
+		case <-g.stop:
+			continue
+		case <-resize:
+			g.dimension = fitTerminal(g.dimension)
+			currentGen = currentGen.Resize(g.dimension)
+			g.setCurrent(generations, currentGen)
+			g.render(generations, currentGen)
+			stale = false
+			continue
+		case <-frames.wait(generations + 1):
+		}
+
+		paused, ok := g.waitWhilePaused()
+		if !ok {
+			continue
+		}
+		frames.delay(paused)
+		if rate, ok := g.speedChange(); ok {
+			frames.retime(generations+1, rate)
+		}
+		if s, ok := g.rewind(); ok {
+			currentGen, generations, ended = s.gen, s.n, false
+			frames.moveTo(generations + 1)
+			if detect != nil {
+				detect = newDetector()
+			}
+		}
+		if ended {
+			fresh, ok := g.restart(frames)
+			if !ok {
+				continue
+			}
+			// the new board counts as a generation, so a run of boards
+			// which end at once still reaches the generation limit
+			currentGen, detect = fresh, newDetector()
+			generations++
+			g.setCurrent(generations, currentGen)
+			g.sparkline.record(currentGen.Population())
+			g.render(generations, currentGen)
+			stale = false
+			ended = g.observe(detect, currentGen, generations)
+			continue
+		}
+
+		began := time.Now()
+		previous := currentGen
 		currentGen = Next(currentGen)
-		g.ui.ClearScreen()
-		g.ui.Write(currentGen.String())
+		computed := time.Since(began)
+		if g.metrics != nil {
+			g.metrics.generation(currentGen.Population(), computed)
+		}
+		generations++
+		g.setCurrent(generations, currentGen)
+		g.sparkline.record(currentGen.Population())
+		// when the next frame is already due, skip drawing this one rather
+		// than fall further behind
+		var drawn time.Duration
+		stale = frames.late(generations + 1)
+		if !stale {
+			drawing := time.Now()
+			g.render(generations, currentGen)
+			drawn = time.Since(drawing)
+		}
+		g.checkBudget(generations, time.Since(began))
+		if err := trace.record(computed, drawn, currentGen); err != nil {
+			g.logf("life: trace file: %v", err)
+		}
+		ended = g.observe(detect, currentGen, generations)
+		g.pauseIfQuiet(previous, currentGen, generations)
+		g.progress.report(generations, g.maxGenerations)
+	}
+	// a skipped frame has no next frame to make up for it at the end
+	if stale {
+		g.render(generations, currentGen)
+	}
+
+	return generations
+}
+
+// initial returns the generation the game starts from: the one given by
+// WithGeneration, or else a new one built from the board size and generation
+// options
+func (g *Game) initial() *Generation {
+	gen := g.seed
+	if gen == nil {
+		gen = g.generate()
+	}
+	g.dimension = gen.Dimension()
+
+	return gen
+}
+
+// generate builds a new board from the board size and generation options,
+// handing the game's logger to a cell generator with warnings to log
+func (g *Game) generate() *Generation {
+	opts := append([]Option{WithDimension(g.dimension)}, g.generationOpts...)
+	opts = append(opts, func(gen *Generation) {
+		if l, ok := gen.generator.(logReceiver); ok {
+			l.setLogger(g.logger)
+		}
+	})
+
+	return NewGeneration(opts...)
+}
+
+// checkBudget warns when producing the nth generation took longer than the
+// generation rate, which means the game is falling behind
+func (g *Game) checkBudget(n int, took time.Duration) {
+	if g.rate <= 0 || took <= g.rate {
+		return
+	}
+
+	g.logf("life: generation %d took %v, longer than the %v generation rate", n, took, g.rate)
+}
+
+// logf logs a warning to the logger set by WithLogger, if there is one
+func (g *Game) logf(format string, v ...interface{}) {
+	if g.logger != nil {
+		g.logger.Printf(format, v...)
+	}
+}
+
+// logReceiver is a UI or cell generator with warnings of its own to log. A
+// game hands it the logger set by WithLogger, which may be nil.
+type logReceiver interface {
+	setLogger(l *log.Logger)
+}
+
+// observe passes the nth generation to the detector, notifying of any event
+// it triggers, and reports whether the event calls for WithAutoRestart to
+// reseed the board. Without a detector nothing is observed.
+func (g *Game) observe(d *detector, gen *Generation, n int) bool {
+	if d == nil {
+		return false
+	}
+
+	e, ok := d.observe(gen, n)
+	if !ok {
+		return false
+	}
+	if g.notify != nil {
+		g.notify(e)
+	}
+	if g.metrics != nil {
+		g.metrics.event(e)
+	}
+
+	return g.autoRestart && (e.Kind == Extinct || e.Kind == Stable || e.Kind == Cycle)
+}
+
+// pauseIfQuiet pauses the game when the step from previous to the nth
+// generation gen changed fewer cells than the quiescence threshold, having
+// changed more before it. Without a threshold nothing happens.
+func (g *Game) pauseIfQuiet(previous, gen *Generation, n int) {
+	if g.quietThreshold <= 0 {
+		return
+	}
+
+	changed, err := ChangeCount(previous, gen)
+	if err != nil {
+		return
+	}
+
+	wasQuiet := g.quiet
+	g.quiet = changed < g.quietThreshold
+	if !g.quiet || wasQuiet {
+		return
+	}
+
+	g.Pause()
+	if g.notify != nil {
+		g.notify(Event{Kind: Quiescent, Generation: n})
+	}
+}
+
+// fitTerminal returns the largest board which fits the terminal attached to
+// standard output, falling back to d when the terminal size is unknown. Each
+// cell is two columns wide and the last row is left for the cursor.
+func fitTerminal(d Dimension) Dimension {
+	cols, rows, err := terminalSize(os.Stdout)
+	if err != nil || cols < 2 || rows < 2 {
+		return d
+	}
+
+	return Dimension{X: cols / 2, Y: rows - 1}
+}
+
+// Stop ends the game, causing Run to return. It is safe to call Stop more than
+// once and from any goroutine.
+func (g *Game) Stop() {
+	g.stopOnce.Do(func() {
+		close(g.stop)
+	})
+}
+
+// Pause suspends a running game until Resume is called. Pausing a paused game
+// has no effect.
+func (g *Game) Pause() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.resumed == nil {
+		g.resumed = make(chan struct{})
+	}
+}
+
+// Resume continues a paused game. Resuming a game which is not paused has no
+// effect.
+func (g *Game) Resume() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.resumed != nil {
+		close(g.resumed)
+		g.resumed = nil
 	}
+	g.steps = 0
+}
+
+// waitWhilePaused blocks while the game is paused, unless Step allows it a
+// generation, returning how long it waited and false if the game was stopped
+// in the meantime
+func (g *Game) waitWhilePaused() (time.Duration, bool) {
+	var waited time.Duration
+	for {
+		g.mu.Lock()
+		resumed := g.resumed
+		stepped := resumed != nil && g.steps > 0
+		if stepped {
+			g.steps--
+		}
+		g.mu.Unlock()
+
+		if resumed == nil || stepped {
+			return waited, true
+		}
+
+		began := time.Now()
+
+		select {
+		case <-resumed:
+			waited += time.Since(began)
+		case <-g.stop:
+			return waited + time.Since(began), false
+		}
+	}
+}
+
+// handlePauseSignals pauses and resumes the game as pause signals arrive until
+// the returned function is called
+func (g *Game) handlePauseSignals() func() {
+	pause, resume, stop := notifyPause()
+	done := make(chan struct{})
+
+	go func() {
+		for {
+			select {
+			case <-pause:
+				g.Pause()
+			case <-resume:
+				g.Resume()
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		stop()
+		close(done)
+	}
+}
+
+// done reports whether a game which has produced n generations over elapsed
+// time, the latest being gen, has been stopped or has reached one of its
+// limits, recording the reason for StopReason
+func (g *Game) done(n int, elapsed time.Duration, gen *Generation) bool {
+	reason := Running
+	select {
+	case <-g.stop:
+		reason = Stopped
+	default:
+		switch {
+		case g.maxGenerations > 0 && n >= g.maxGenerations:
+			reason = MaxGenerationsReached
+		case g.maxDuration > 0 && elapsed >= g.maxDuration:
+			reason = MaxDurationReached
+		case g.maxPopulation > 0 && gen.Population() > g.maxPopulation:
+			reason = MaxPopulationExceeded
+		}
+	}
+
+	g.mu.Lock()
+	g.reason = reason
+	g.mu.Unlock()
+
+	return reason != Running
+}
+
+// setCurrent records gen, the nth generation, as the one most recently
+// produced
+func (g *Game) setCurrent(n int, gen *Generation) {
+	g.mu.Lock()
+	g.remember(n)
+	g.current, g.currentN = gen, n
+	g.publish(n, gen)
+	g.mu.Unlock()
+
+	g.recordFootprint(gen)
+}
+
+// Current returns a copy of the generation most recently produced, or nil
+// before the game has started. It is safe to call from other goroutines while
+// the game runs, for example to serve the board over HTTP.
+func (g *Game) Current() *Generation {
+	g.mu.Lock()
+	current := g.current
+	g.mu.Unlock()
+
+	if current == nil {
+		return nil
+	}
+
+	return current.successor(append([]Cell(nil), current.cells...))
+}
+
+// StopReason reports why the game stopped, or Running while it is still
+// being played
+func (g *Game) StopReason() StopReason {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.reason
+}
+
+// render draws the nth generation, skipping the work of building the frame
+// when rendering is disabled
+func (g *Game) render(n int, gen *Generation) {
+	// StepBack draws from another goroutine
+	g.drawing.Lock()
+	defer g.drawing.Unlock()
+
+	switch ui := g.ui.(type) {
+	case NopUI:
+		return
+	case frameUI:
+		// a GenerationUI too, but one which is given the frame below
+	case GenerationUI:
+		ui.WriteGeneration(n, gen)
+		return
+	}
+
+	frame := g.applyTemplate(n, gen, gen.Render(g.renderOpts...))
+	if g.sparkline != nil {
+		frame = g.sparkline.header() + frame
+	}
+
+	if ui, ok := g.ui.(frameUI); ok {
+		ui.writeFrame(n, gen, frame)
+		return
+	}
+	g.ui.ClearScreen()
+	g.ui.Write(frame)
+}
+
+// frameUI is a UI which lays out the frame the game draws along with
+// something of its own, so it needs the generation beside the frame
+type frameUI interface {
+	UI
+	writeFrame(n int, gen *Generation, frame string)
 }
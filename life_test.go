@@ -1,7 +1,17 @@
 package life_test
 
 import (
+	"bytes"
+	"fmt"
+	"hash/fnv"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+	"text/template"
+	"time"
 
 	"github.com/enocom/life"
 )
@@ -85,6 +95,33 @@ func TestNext(t *testing.T) {
 	}
 }
 
+func TestNextBirthWithoutNeighbors(t *testing.T) {
+	// under B0 every dead cell with no live neighbors is born, so an empty
+	// board fills up
+	g := life.NewGeneration(
+		life.WithDimension(life.Dimension{X: 3, Y: 3}),
+		life.WithCells(newBoard(life.Dimension{X: 3, Y: 3}).Cells()),
+		life.WithRule(mustParseRule(t, "B0/S8")),
+	)
+
+	if got := life.Next(g).Population(); got != 9 {
+		t.Errorf("want: 9 live cells, got: %v", got)
+	}
+}
+
+func TestNextErr(t *testing.T) {
+	g := life.NewGeneration(life.WithDimension(life.Dimension{X: -1, Y: 2}))
+
+	if _, err := life.NextErr(g); err == nil {
+		t.Error("want: an error for a malformed generation, got: nil")
+	}
+
+	g = life.NewGeneration(life.WithDimension(life.Dimension{X: 2, Y: 2}))
+	if _, err := life.NextErr(g); err != nil {
+		t.Errorf("want: no error, got: %v", err)
+	}
+}
+
 func equal(a, b []life.Cell) bool {
 	if len(a) != len(b) {
 		return false
@@ -118,6 +155,42 @@ func TestGenerationString(t *testing.T) {
 	}
 }
 
+func TestGenerationDimension(t *testing.T) {
+	d := life.Dimension{X: 4, Y: 2}
+	g := life.NewGeneration(life.WithDimension(d))
+
+	if got := g.Dimension(); got != d {
+		t.Errorf("want: %v, got: %v", d, got)
+	}
+
+	if got := life.Next(g).Dimension(); got != d {
+		t.Errorf("want: %v, got: %v", d, got)
+	}
+}
+
+func TestResize(t *testing.T) {
+	// o -
+	// - o
+	g := newBoard(life.Dimension{X: 2, Y: 2}, [2]int{0, 0}, [2]int{1, 1})
+
+	grown := g.Resize(life.Dimension{X: 3, Y: 3})
+	expected := "o    \n  o  \n     \n"
+	if display := grown.String(); display != expected {
+		t.Errorf("want: %#v, got: %#v", expected, display)
+	}
+
+	shrunk := grown.Resize(life.Dimension{X: 1, Y: 2})
+	expected = "o\n \n"
+	if display := shrunk.String(); display != expected {
+		t.Errorf("want: %#v, got: %#v", expected, display)
+	}
+
+	gone := g.Resize(life.Dimension{X: -1, Y: 2})
+	if d := gone.Dimension(); d != (life.Dimension{X: 0, Y: 2}) || len(gone.Cells()) != 0 {
+		t.Errorf("want: a 0x2 board with no cells, got: %v with %d cells", d, len(gone.Cells()))
+	}
+}
+
 func TestLeftEdge(t *testing.T) {
 	d := life.Dimension{X: 3, Y: 3}
 
@@ -160,4 +233,900 @@ func TestRightEdge(t *testing.T) {
 			t.Errorf("want: false, got: %v (idx = %v)", result, n)
 		}
 	}
+
+	// on a board one cell wide every cell is on both edges
+	column := life.Dimension{X: 1, Y: 3}
+	for idx := 0; idx < 3; idx++ {
+		if !column.RightEdge(idx) || !column.LeftEdge(idx) {
+			t.Errorf("want: an edge, got: none (idx = %v)", idx)
+		}
+	}
+}
+
+func TestRunMaxGenerations(t *testing.T) {
+	g := life.NewGame(
+		life.WithUI(nil),
+		life.WithGenerationRate(0),
+		life.WithMaxGenerations(5),
+	)
+
+	got := g.Run()
+	if got != 5 {
+		t.Errorf("want: 5, got: %v", got)
+	}
+}
+
+func TestRunProgress(t *testing.T) {
+	var buf bytes.Buffer
+	g := life.NewGame(
+		life.WithUI(nil),
+		life.WithGenerationRate(0),
+		life.WithMaxGenerations(200),
+		life.WithProgress(&buf),
+	)
+	start := time.Now()
+	g.Run()
+	elapsed := time.Since(start)
+
+	// the first generation and the last are always reported, and between
+	// them at most one update per quarter second of the run
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if most := 2 + int(elapsed/(250*time.Millisecond)); len(lines) > most {
+		t.Errorf("want: at most %v lines in %v, got %v", most, elapsed, len(lines))
+	}
+	if first, want := lines[0], "generation 1/200 (0%)"; first != want {
+		t.Errorf("want: %#v, got: %#v", want, first)
+	}
+	previous := 0
+	for _, line := range lines {
+		var n, total, percent int
+		if _, err := fmt.Sscanf(line, "generation %d/%d (%d%%)", &n, &total, &percent); err != nil ||
+			total != 200 || percent != n*100/200 || n <= previous {
+			t.Errorf("want: a report of a later generation than %v, got: %#v", previous, line)
+		}
+		previous = n
+	}
+	if last, want := lines[len(lines)-1], "generation 200/200 (100%)"; last != want {
+		t.Errorf("want: %#v, got: %#v", want, last)
+	}
+}
+
+func TestRunTraceFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "trace.csv")
+	glider := newBoard(life.Dimension{X: 8, Y: 8},
+		[2]int{1, 0}, [2]int{2, 1}, [2]int{0, 2}, [2]int{1, 2}, [2]int{2, 2},
+	)
+	g := life.NewGame(
+		life.WithUI(&recordingUI{}),
+		life.WithGenerationRate(0),
+		life.WithMaxGenerations(3),
+		life.WithGeneration(glider),
+		life.WithTraceFile(path),
+	)
+	g.Run()
+
+	trace, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows := strings.Split(strings.TrimSpace(string(trace)), "\n")
+	if len(rows) != 4 || rows[0] != "compute_ns,render_ns,population" {
+		t.Fatalf("want: a header and 3 rows, got: %q", rows)
+	}
+	for _, row := range rows[1:] {
+		var compute, render, population int
+		if _, err := fmt.Sscanf(row, "%d,%d,%d", &compute, &render, &population); err != nil {
+			t.Fatalf("row %q: %v", row, err)
+		}
+		if compute <= 0 || render <= 0 || population != 5 {
+			t.Errorf("want: positive timings and a population of 5, got: %q", row)
+		}
+	}
+}
+
+func TestHexagonalNeighborhood(t *testing.T) {
+	// The center cell sits on an odd row, so on a hexagonal board the cells
+	// in the left column are only its neighbor in the same row.
+	//
+	// o - -
+	// o - -
+	// o - -
+	before := []life.Cell{
+		life.NewLiveCell(), life.NewDeadCell(), life.NewDeadCell(),
+		life.NewLiveCell(), life.NewDeadCell(), life.NewDeadCell(),
+		life.NewLiveCell(), life.NewDeadCell(), life.NewDeadCell(),
+	}
+
+	testCases := map[string]struct {
+		n    life.Neighborhood
+		want life.Cell
+	}{
+		"Moore":     {n: life.Moore, want: life.NewLiveCell()},
+		"Hexagonal": {n: life.Hexagonal, want: life.NewDeadCell()},
+	}
+
+	for description, tc := range testCases {
+		g := life.NewGeneration(
+			life.WithDimension(life.Dimension{X: 3, Y: 3}),
+			life.WithNeighborhood(tc.n),
+			life.WithCells(before),
+		)
+
+		got := life.Next(g).Cells()[4]
+		if got != tc.want {
+			t.Errorf("(%s): want %v, got %v", description, tc.want, got)
+		}
+	}
+}
+
+func TestBoundaryState(t *testing.T) {
+	// With a live boundary, each dead cell in the middle of an edge has three
+	// live neighbors off the board and is born, while the corners have five.
+	g := life.NewGeneration(
+		life.WithDimension(life.Dimension{X: 3, Y: 3}),
+		life.WithBoundaryState(life.NewLiveCell()),
+		life.WithCells(make([]life.Cell, 9)),
+	)
+
+	// - o -
+	// o - o
+	// - o -
+	got := life.Next(g)
+	want := newBoard(life.Dimension{X: 3, Y: 3},
+		[2]int{1, 0}, [2]int{0, 1}, [2]int{2, 1}, [2]int{1, 2},
+	)
+	if !equal(got.Cells(), want.Cells()) {
+		t.Errorf("want: %v, got: %v", want.Cells(), got.Cells())
+	}
+
+	// the boundary carries over, overcrowding the cells born on the edges
+	got = life.Next(got)
+	want = newBoard(life.Dimension{X: 3, Y: 3})
+	if !equal(got.Cells(), want.Cells()) {
+		t.Errorf("want: %v, got: %v", want.Cells(), got.Cells())
+	}
+}
+
+func TestNextWith(t *testing.T) {
+	// o o -
+	// - - -
+	// - - -
+	g := newBoard(life.Dimension{X: 3, Y: 3}, [2]int{0, 0}, [2]int{1, 0})
+
+	// a dead cell with exactly two live neighbors is born and every live cell
+	// dies, as in the Seeds rule
+	seeds := func(c life.Cell, liveNeighbors int) life.Cell {
+		if !c.Alive() && liveNeighbors == 2 {
+			return life.NewLiveCell()
+		}
+		return life.NewDeadCell()
+	}
+
+	got := life.NextWith(g, seeds)
+	want := newBoard(life.Dimension{X: 3, Y: 3}, [2]int{0, 1}, [2]int{1, 1})
+	if !equal(got.Cells(), want.Cells()) {
+		t.Errorf("want: %v, got: %v", want, got)
+	}
+}
+
+func TestMask(t *testing.T) {
+	// a blinker whose lower half cannot grow:
+	//
+	// - - -
+	// o o o
+	// x x x
+	d := life.Dimension{X: 3, Y: 3}
+	g := life.NewGeneration(
+		life.WithDimension(d),
+		life.WithCells(newBoard(d, [2]int{0, 1}, [2]int{1, 1}, [2]int{2, 1}, [2]int{1, 2}).Cells()),
+		life.WithMask(func(x, y int) bool { return y < 2 }),
+	)
+
+	// the masked cell starts dead despite the seed
+	if want := newBoard(d, [2]int{0, 1}, [2]int{1, 1}, [2]int{2, 1}); !equal(g.Cells(), want.Cells()) {
+		t.Errorf("want: %v, got: %v", want, g)
+	}
+
+	got := life.Next(g)
+	if want := newBoard(d, [2]int{1, 0}, [2]int{1, 1}); !equal(got.Cells(), want.Cells()) {
+		t.Errorf("want: %v, got: %v", want, got)
+	}
+}
+
+func TestWrap(t *testing.T) {
+	// a glider moves one cell diagonally every four generations, so on a 6x6
+	// torus it crosses both seams and returns to where it started after 24
+	d := life.Dimension{X: 6, Y: 6}
+	start := newBoard(d, [2]int{1, 0}, [2]int{2, 1}, [2]int{0, 2}, [2]int{1, 2}, [2]int{2, 2})
+	g := life.NewGeneration(life.WithDimension(d), life.WithCells(start.Cells()), life.WithWrap())
+
+	for i := 0; i < 24; i++ {
+		g = life.Next(g)
+		if got := g.Population(); got != 5 {
+			t.Fatalf("generation %d: want: 5 live cells, got: %v\n%v", i+1, got, g)
+		}
+	}
+	if !equal(g.Cells(), start.Cells()) {
+		t.Errorf("want: %v, got: %v", start, g)
+	}
+}
+
+func TestNeighborCounts(t *testing.T) {
+	d := life.Dimension{X: 3, Y: 3}
+	blinker := newBoard(d, [2]int{0, 1}, [2]int{1, 1}, [2]int{2, 1}).Cells()
+
+	testCases := map[string]struct {
+		opts []life.Option
+		want []int
+	}{
+		"plain": {
+			want: []int{
+				2, 3, 2,
+				1, 2, 1,
+				2, 3, 2,
+			},
+		},
+		"wrapped, where every other cell is a neighbor": {
+			opts: []life.Option{life.WithWrap()},
+			want: []int{
+				3, 3, 3,
+				2, 2, 2,
+				3, 3, 3,
+			},
+		},
+		"masked": {
+			opts: []life.Option{life.WithMask(func(x, y int) bool { return y < 2 })},
+			want: []int{
+				2, 3, 2,
+				1, 2, 1,
+				0, 0, 0,
+			},
+		},
+	}
+
+	for description, tc := range testCases {
+		opts := append([]life.Option{life.WithDimension(d), life.WithCells(blinker)}, tc.opts...)
+		got := life.NewGeneration(opts...).NeighborCounts()
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("(%s): want: %v, got: %v", description, tc.want, got)
+		}
+	}
+}
+
+func TestVonNeumannNeighborhood(t *testing.T) {
+	// o - o
+	// - - -
+	// o - -
+	before := []life.Cell{
+		life.NewLiveCell(), life.NewDeadCell(), life.NewLiveCell(),
+		life.NewDeadCell(), life.NewDeadCell(), life.NewDeadCell(),
+		life.NewLiveCell(), life.NewDeadCell(), life.NewDeadCell(),
+	}
+
+	testCases := map[string]struct {
+		n    life.Neighborhood
+		want life.Cell
+	}{
+		"Moore":      {n: life.Moore, want: life.NewLiveCell()},
+		"VonNeumann": {n: life.VonNeumann, want: life.NewDeadCell()},
+	}
+
+	for description, tc := range testCases {
+		g := life.NewGeneration(
+			life.WithDimension(life.Dimension{X: 3, Y: 3}),
+			life.WithNeighborhood(tc.n),
+			life.WithCells(before),
+		)
+
+		got := life.Next(g).Cells()[4]
+		if got != tc.want {
+			t.Errorf("(%s): want %v, got %v", description, tc.want, got)
+		}
+	}
+}
+
+func TestHexagonalString(t *testing.T) {
+	g := life.NewGeneration(
+		life.WithDimension(life.Dimension{X: 2, Y: 2}),
+		life.WithNeighborhood(life.Hexagonal),
+		life.WithCells([]life.Cell{
+			life.NewLiveCell(),
+			life.NewDeadCell(),
+
+			life.NewDeadCell(),
+			life.NewLiveCell(),
+		}),
+	)
+
+	display := g.String()
+	expected := "o  \n   o\n"
+	if display != expected {
+		t.Errorf("want: %#v, got: %#v", expected, display)
+	}
+
+	display = life.Next(g).String()
+	expected = "   \n    \n"
+	if display != expected {
+		t.Errorf("want: Next to preserve the neighborhood %#v, got: %#v", expected, display)
+	}
+}
+
+func TestRunMaxDuration(t *testing.T) {
+	g := life.NewGame(
+		life.WithUI(nil),
+		life.WithGenerationRate(time.Millisecond),
+		life.WithMaxDuration(20*time.Millisecond),
+	)
+
+	start := time.Now()
+	got := g.Run()
+	elapsed := time.Since(start)
+
+	if got == 0 {
+		t.Error("want: at least one generation, got: 0")
+	}
+	if elapsed > time.Second {
+		t.Errorf("want: run to stop after about 20ms, took: %v", elapsed)
+	}
+}
+
+func TestRunFirstLimitWins(t *testing.T) {
+	g := life.NewGame(
+		life.WithUI(nil),
+		life.WithGenerationRate(0),
+		life.WithMaxGenerations(3),
+		life.WithMaxDuration(time.Hour),
+	)
+
+	got := g.Run()
+	if got != 3 {
+		t.Errorf("want: 3, got: %v", got)
+	}
+	if r := g.StopReason(); r != life.MaxGenerationsReached {
+		t.Errorf("want: %v, got: %v", life.MaxGenerationsReached, r)
+	}
+}
+
+func TestRunMaxPopulation(t *testing.T) {
+	// an R-pentomino grows from five cells to six in the first generation
+	seed := newBoard(life.Dimension{X: 20, Y: 20},
+		[2]int{10, 9}, [2]int{11, 9}, [2]int{9, 10}, [2]int{10, 10}, [2]int{10, 11})
+	g := life.NewGame(
+		life.WithUI(nil),
+		life.WithGenerationRate(0),
+		life.WithMaxGenerations(100),
+		life.WithMaxPopulation(5),
+		life.WithGeneration(seed),
+	)
+
+	got := g.Run()
+	if got != 1 {
+		t.Errorf("want: 1, got: %v", got)
+	}
+	if r := g.StopReason(); r != life.MaxPopulationExceeded {
+		t.Errorf("want: %v, got: %v", life.MaxPopulationExceeded, r)
+	}
+}
+
+func TestRunStop(t *testing.T) {
+	g := life.NewGame(
+		life.WithUI(nil),
+		life.WithGenerationRate(time.Millisecond),
+	)
+
+	done := make(chan int)
+	go func() {
+		done <- g.Run()
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	g.Stop()
+	g.Stop()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("want: Run to return after Stop")
+	}
+	if r := g.StopReason(); r != life.Stopped {
+		t.Errorf("want: %v, got: %v", life.Stopped, r)
+	}
+}
+
+func TestRunWithGeneration(t *testing.T) {
+	var ui recordingUI
+	seed := newBoard(life.Dimension{X: 4, Y: 4}, [2]int{1, 1}, [2]int{2, 1}, [2]int{1, 2}, [2]int{2, 2})
+	g := life.NewGame(
+		life.WithUI(&ui),
+		life.WithGenerationRate(0),
+		life.WithMaxGenerations(1),
+		life.WithGeneration(seed),
+	)
+	g.Run()
+
+	want := []string{seed.String(), seed.String()}
+	if !reflect.DeepEqual(ui.frames, want) {
+		t.Errorf("want: %#v, got: %#v", want, ui.frames)
+	}
+}
+
+// recordingUI keeps every frame written to it
+type recordingUI struct {
+	frames []string
+}
+
+func (r *recordingUI) ClearScreen() {}
+
+func (r *recordingUI) Write(frame string) {
+	r.frames = append(r.frames, frame)
+}
+
+func TestPopulation(t *testing.T) {
+	g := newBoard(life.Dimension{X: 3, Y: 3}, [2]int{0, 0}, [2]int{1, 1}, [2]int{2, 2})
+
+	if got := g.Population(); got != 3 {
+		t.Errorf("want: 3, got: %v", got)
+	}
+}
+
+func TestIsEmpty(t *testing.T) {
+	empty := newBoard(life.Dimension{X: 3, Y: 3})
+	if !empty.IsEmpty() {
+		t.Error("want: an empty board to be empty")
+	}
+
+	next := life.Next(empty)
+	if !next.IsEmpty() || next.Dimension() != empty.Dimension() {
+		t.Errorf("want: an empty 3x3 board, got: %#v", next.String())
+	}
+
+	if newBoard(life.Dimension{X: 3, Y: 3}, [2]int{1, 1}).IsEmpty() {
+		t.Error("want: a board with a live cell not to be empty")
+	}
+}
+
+func BenchmarkNextEmpty(b *testing.B) {
+	g := newBoard(life.Dimension{X: 256, Y: 256})
+
+	for i := 0; i < b.N; i++ {
+		life.Next(g)
+	}
+}
+
+func BenchmarkNextRandom(b *testing.B) {
+	g := life.NewGeneration(life.WithDimension(life.Dimension{X: 256, Y: 256}))
+
+	for i := 0; i < b.N; i++ {
+		life.Next(g)
+	}
+}
+
+func TestPauseResume(t *testing.T) {
+	g := life.NewGame(
+		life.WithUI(nil),
+		life.WithGenerationRate(0),
+		life.WithMaxGenerations(3),
+	)
+	g.Pause()
+
+	done := make(chan int)
+	go func() {
+		done <- g.Run()
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("want: a paused game not to finish")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	g.Resume()
+	select {
+	case got := <-done:
+		if got != 3 {
+			t.Errorf("want: 3, got: %v", got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("want: a resumed game to finish")
+	}
+}
+
+func TestRunWarnsWhenBehind(t *testing.T) {
+	var buf bytes.Buffer
+	g := life.NewGame(
+		life.WithUI(nil),
+		life.WithBoardSize(50),
+		life.WithGenerationRate(time.Nanosecond),
+		life.WithMaxGenerations(2),
+		life.WithLogger(log.New(&buf, "", 0)),
+	)
+	g.Run()
+
+	if !strings.Contains(buf.String(), "longer than the 1ns generation rate") {
+		t.Errorf("want: a warning about falling behind, got: %#v", buf.String())
+	}
+}
+
+// slowUI takes longer to draw each frame than the generation rate allows
+type slowUI struct {
+	recordingUI
+	delay time.Duration
+}
+
+func (s *slowUI) Write(frame string) {
+	time.Sleep(s.delay)
+	s.recordingUI.Write(frame)
+}
+
+func TestRunSkipsLateFrames(t *testing.T) {
+	glider := newBoard(life.Dimension{X: 8, Y: 8},
+		[2]int{1, 0}, [2]int{2, 1}, [2]int{0, 2}, [2]int{1, 2}, [2]int{2, 2},
+	)
+	final := glider
+	for i := 0; i < 10; i++ {
+		final = life.Next(final)
+	}
+
+	// drawing a frame takes as long as twenty generations are given, so the
+	// frame after any drawn one is always late and skipped
+	ui := &slowUI{delay: 20 * time.Millisecond}
+	g := life.NewGame(
+		life.WithUI(ui),
+		life.WithGenerationRate(time.Millisecond),
+		life.WithMaxGenerations(10),
+		life.WithGeneration(glider),
+	)
+
+	if got := g.Run(); got != 10 {
+		t.Errorf("want: 10 generations, got: %v", got)
+	}
+	if len(ui.frames) >= 11 {
+		t.Errorf("want: late frames skipped, got: %v frames", len(ui.frames))
+	}
+	// the last generation is drawn even when its frame is late
+	if len(ui.frames) < 2 || ui.frames[0] != glider.String() || ui.frames[len(ui.frames)-1] != final.String() {
+		t.Errorf("want: the first and last generations drawn, %#v and %#v, got: %#v",
+			glider.String(), final.String(), ui.frames)
+	}
+}
+
+func TestCurrent(t *testing.T) {
+	g := life.NewGame(
+		life.WithUI(nil),
+		life.WithGenerationRate(0),
+		life.WithMaxGenerations(200),
+	)
+	if g.Current() != nil {
+		t.Error("want: no current generation before the game starts")
+	}
+
+	done := make(chan struct{})
+	go func() {
+		g.Run()
+		close(done)
+	}()
+
+	// read the board while the game advances it; run with -race to check
+	// for unsynchronized access
+	for running := true; running; {
+		select {
+		case <-done:
+			running = false
+		default:
+		}
+		if c := g.Current(); c != nil {
+			_ = c.Population()
+		}
+	}
+
+	c := g.Current()
+	if c == nil {
+		t.Fatal("want: the final generation, got: nil")
+	}
+	if d := (life.Dimension{X: 10, Y: 10}); c.Dimension() != d {
+		t.Errorf("want: %v, got: %v", d, c.Dimension())
+	}
+}
+
+func TestPlainUI(t *testing.T) {
+	var buf bytes.Buffer
+	seed := newBoard(life.Dimension{X: 3, Y: 3}, [2]int{0, 1}, [2]int{1, 1}, [2]int{2, 1})
+	g := life.NewGame(
+		life.WithUI(life.NewPlainUI(&buf)),
+		life.WithGenerationRate(0),
+		life.WithMaxGenerations(1),
+		life.WithGeneration(seed),
+	)
+	g.Run()
+
+	want := "" +
+		"--- frame 0 ---\n" +
+		"     \n" +
+		"o o o\n" +
+		"     \n" +
+		"--- frame 1 ---\n" +
+		"  o  \n" +
+		"  o  \n" +
+		"  o  \n"
+	if got := buf.String(); got != want {
+		t.Errorf("want: %#v, got: %#v", want, got)
+	}
+}
+
+func TestRunPopulationSparkline(t *testing.T) {
+	var ui recordingUI
+	seed := newBoard(life.Dimension{X: 3, Y: 3}, [2]int{0, 0}, [2]int{1, 1}, [2]int{2, 2})
+	g := life.NewGame(
+		life.WithUI(&ui),
+		life.WithGenerationRate(0),
+		life.WithMaxGenerations(2),
+		life.WithGeneration(seed),
+		life.WithPopulationSparkline(2),
+	)
+	g.Run()
+
+	var headers []string
+	for _, frame := range ui.frames {
+		headers = append(headers, strings.SplitN(frame, "\n", 2)[0])
+	}
+
+	want := []string{"population 3 ▁", "population 1 █▁", "population 0 █▁"}
+	if !reflect.DeepEqual(headers, want) {
+		t.Errorf("want: %#v, got: %#v", want, headers)
+	}
+}
+
+func TestAutoPauseOnQuiescence(t *testing.T) {
+	// three cells which grow into a block, changing a single cell
+	var ui syncUI
+	seed := newBoard(life.Dimension{X: 4, Y: 4}, [2]int{1, 1}, [2]int{2, 1}, [2]int{1, 2})
+	events := make(chan life.Event, 10)
+	g := life.NewGame(
+		life.WithUI(&ui),
+		life.WithGenerationRate(0),
+		life.WithMaxGenerations(5),
+		life.WithGeneration(seed),
+		life.WithAutoPauseOnQuiescence(2),
+		life.WithNotify(func(e life.Event) {
+			if e.Kind == life.Quiescent {
+				events <- e
+			}
+		}),
+	)
+
+	done := make(chan int)
+	go func() { done <- g.Run() }()
+
+	if e := <-events; e.Generation != 1 {
+		t.Errorf("want: a quiescent event at generation 1, got: %+v", e)
+	}
+	time.Sleep(10 * time.Millisecond)
+	if got := ui.count(); got != 2 {
+		t.Errorf("want: the game to pause after 2 frames, got: %v", got)
+	}
+
+	g.Resume()
+	if got := <-done; got != 5 {
+		t.Errorf("want: 5 generations, got: %v", got)
+	}
+	if len(events) != 0 {
+		t.Errorf("want: no further pause while the board stays quiet, got: %+v", <-events)
+	}
+}
+
+// countingWriter records each write made to it separately
+type countingWriter struct {
+	writes []string
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	c.writes = append(c.writes, string(p))
+	return len(p), nil
+}
+
+func TestTerminalUIWritesFrameOnce(t *testing.T) {
+	var w countingWriter
+	g := life.NewGame(
+		life.WithUI(life.NewTerminalUI(&w)),
+		life.WithGenerationRate(0),
+		life.WithMaxGenerations(1),
+		life.WithGeneration(newBoard(life.Dimension{X: 3, Y: 3}, [2]int{0, 1}, [2]int{1, 1}, [2]int{2, 1})),
+		life.WithPopulationSparkline(2),
+	)
+	g.Run()
+
+	want := []string{
+		"\033[H\033[2J" + "population 3 ▁\n" + "     \no o o\n     \n",
+		"\033[H\033[2J" + "population 3 ▁▁\n" + "  o  \n  o  \n  o  \n",
+	}
+	if !reflect.DeepEqual(w.writes, want) {
+		t.Errorf("want: one write per frame %#v, got: %#v", want, w.writes)
+	}
+}
+
+// checkerboard is a PositionalCellGenerator whose Generate would fail the
+// test, as NewGeneration should never call it
+type checkerboard struct {
+	t *testing.T
+}
+
+func (c checkerboard) Generate() life.Cell {
+	c.t.Error("want: GenerateAt to be preferred over Generate")
+	return life.NewDeadCell()
+}
+
+func (c checkerboard) GenerateAt(x, y int) life.Cell {
+	if (x+y)%2 == 0 {
+		return life.NewLiveCell()
+	}
+	return life.NewDeadCell()
+}
+
+// alternating is a CellGenerator which knows nothing of positions
+type alternating struct {
+	n int
+}
+
+func (a *alternating) Generate() life.Cell {
+	a.n++
+	if a.n%2 == 1 {
+		return life.NewLiveCell()
+	}
+	return life.NewDeadCell()
+}
+
+func TestWithCellGenerator(t *testing.T) {
+	d := life.Dimension{X: 4, Y: 2}
+
+	got := life.NewGeneration(life.WithDimension(d), life.WithCellGenerator(checkerboard{t: t}))
+	want := newBoard(d, [2]int{0, 0}, [2]int{2, 0}, [2]int{1, 1}, [2]int{3, 1})
+	if !equal(got.Cells(), want.Cells()) {
+		t.Errorf("positional want: %#v, got: %#v", want.String(), got.String())
+	}
+
+	got = life.NewGeneration(life.WithDimension(d), life.WithCellGenerator(&alternating{}))
+	want = newBoard(d, [2]int{0, 0}, [2]int{2, 0}, [2]int{0, 1}, [2]int{2, 1})
+	if !equal(got.Cells(), want.Cells()) {
+		t.Errorf("sequential want: %#v, got: %#v", want.String(), got.String())
+	}
+}
+
+func TestAutoRestart(t *testing.T) {
+	var ui recordingUI
+	// a lone cell dies out at once, after which the board is reseeded from
+	// a seeded random generator every time
+	g := life.NewGame(
+		life.WithUI(&ui),
+		life.WithGenerationRate(0),
+		life.WithMaxGenerations(20),
+		life.WithGeneration(newBoard(life.Dimension{X: 4, Y: 4}, [2]int{1, 1})),
+		life.WithGenerationOptions(life.WithRandomSeed(7)),
+		life.WithAutoRestart(0),
+	)
+
+	if n := g.Run(); n != 20 {
+		t.Errorf("want: 20 generations, got: %v", n)
+	}
+	if g.Restarts() == 0 {
+		t.Fatal("want: the extinct board to be reseeded")
+	}
+
+	reseeded := life.NewGeneration(life.WithDimension(life.Dimension{X: 4, Y: 4}), life.WithRandomSeed(7))
+	found := false
+	for _, frame := range ui.frames {
+		found = found || frame == reseeded.String()
+	}
+	if !found {
+		t.Errorf("want: a frame showing the reseeded board %#v, got: %#v", reseeded.String(), ui.frames)
+	}
+}
+
+func TestAutoRestartCycle(t *testing.T) {
+	blinker := newBoard(life.Dimension{X: 5, Y: 5}, [2]int{1, 2}, [2]int{2, 2}, [2]int{3, 2})
+	g := life.NewGame(
+		life.WithUI(life.NopUI{}),
+		life.WithGenerationRate(0),
+		life.WithMaxGenerations(20),
+		life.WithGeneration(blinker),
+		life.WithGenerationOptions(life.WithCells(blinker.Cells())),
+		life.WithAutoRestart(0),
+	)
+
+	g.Run()
+	if g.Restarts() == 0 {
+		t.Error("want: a board which settles into a cycle to be reseeded")
+	}
+}
+
+func TestAutoRestartEmptySeed(t *testing.T) {
+	d := life.Dimension{X: 4, Y: 4}
+	g := life.NewGame(
+		life.WithUI(life.NopUI{}),
+		life.WithGenerationRate(0),
+		life.WithMaxGenerations(10),
+		life.WithGeneration(newBoard(d)),
+		life.WithGenerationOptions(life.WithCells(newBoard(d).Cells())),
+		life.WithAutoRestart(0),
+	)
+
+	ran := make(chan int)
+	go func() { ran <- g.Run() }()
+	select {
+	case n := <-ran:
+		if n != 10 {
+			t.Errorf("want: 10 generations, got: %v", n)
+		}
+	case <-time.After(time.Second):
+		g.Stop()
+		t.Fatal("want: restarts to count towards the generation limit")
+	}
+	if g.Restarts() != 10 {
+		t.Errorf("want: a restart for each generation, got: %v", g.Restarts())
+	}
+}
+
+func TestWithSeedString(t *testing.T) {
+	d := life.Dimension{X: 8, Y: 8}
+	a := life.NewGeneration(life.WithDimension(d), life.WithSeedString("galaxy"))
+	b := life.NewGeneration(life.WithDimension(d), life.WithSeedString("galaxy"))
+	c := life.NewGeneration(life.WithDimension(d), life.WithSeedString("glider"))
+
+	if !equal(a.Cells(), b.Cells()) {
+		t.Errorf("want: the same string to give the same board, got %#v and %#v", a.String(), b.String())
+	}
+	if equal(a.Cells(), c.Cells()) {
+		t.Errorf("want: different strings to give different boards, got %#v twice", a.String())
+	}
+
+	// the 64 bit FNV-1a hash of "galaxy"
+	h := fnv.New64a()
+	h.Write([]byte("galaxy"))
+	seeded := life.NewGeneration(life.WithDimension(d), life.WithRandomSeed(int64(h.Sum64())))
+	if !equal(a.Cells(), seeded.Cells()) {
+		t.Errorf("want: the same board as WithRandomSeed with the hash, got %#v and %#v", a.String(), seeded.String())
+	}
+}
+
+func TestFrameTemplate(t *testing.T) {
+	var ui recordingUI
+	tmpl := template.Must(template.New("frame").Parse(
+		"Gen {{.Gen}} | Pop {{.Pop}} | {{printf \"%.2f\" .Density}} | {{.Rule}}\n{{.Board}}--\n",
+	))
+	g := life.NewGame(
+		life.WithUI(&ui),
+		life.WithGenerationRate(0),
+		life.WithMaxGenerations(1),
+		life.WithGeneration(newBoard(life.Dimension{X: 3, Y: 3}, [2]int{0, 1}, [2]int{1, 1}, [2]int{2, 1})),
+		life.WithFrameTemplate(tmpl),
+	)
+	g.Run()
+
+	want := []string{
+		"Gen 0 | Pop 3 | 0.33 | B3/S23\n     \no o o\n     \n--\n",
+		"Gen 1 | Pop 3 | 0.33 | B3/S23\n  o  \n  o  \n  o  \n--\n",
+	}
+	if !reflect.DeepEqual(ui.frames, want) {
+		t.Errorf("want: %#v, got: %#v", want, ui.frames)
+	}
+}
+
+func TestFrameTemplateError(t *testing.T) {
+	var ui recordingUI
+	var logs bytes.Buffer
+	g := life.NewGame(
+		life.WithUI(&ui),
+		life.WithGenerationRate(0),
+		life.WithMaxGenerations(1),
+		life.WithGeneration(newBoard(life.Dimension{X: 1, Y: 1}, [2]int{0, 0})),
+		life.WithFrameTemplate(template.Must(template.New("frame").Parse("{{.Missing}}"))),
+		life.WithLogger(log.New(&logs, "", 0)),
+	)
+	g.Run()
+
+	if ui.frames[0] != "o\n" {
+		t.Errorf("want: the board alone, got: %#v", ui.frames[0])
+	}
+	if !strings.Contains(logs.String(), "frame template") {
+		t.Errorf("want: the failure logged, got: %#v", logs.String())
+	}
 }
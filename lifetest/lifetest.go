@@ -0,0 +1,69 @@
+// Package lifetest provides helpers for testing code built on package life.
+package lifetest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/enocom/life"
+)
+
+// AssertBoardsEqual reports an error through t unless want and got have the
+// same dimensions and the same state in every cell. The report draws both
+// boards side by side, 'o' for a live cell and '.' for a dead one, next to a
+// third board marking each cell which differs with 'x'.
+func AssertBoardsEqual(t testing.TB, want, got *life.Generation) {
+	t.Helper()
+
+	if want.Dimension() != got.Dimension() {
+		t.Errorf("boards differ in size: want %dx%d, got %dx%d\nwant:\n%s\ngot:\n%s",
+			want.Dimension().X, want.Dimension().Y, got.Dimension().X, got.Dimension().Y,
+			want.Render(life.WithDeadGlyph(".")), got.Render(life.WithDeadGlyph(".")))
+		return
+	}
+
+	changes, _ := life.Diff(want, got)
+	if len(changes) == 0 {
+		return
+	}
+
+	t.Errorf("boards differ in %d cells:\n%s", len(changes), sideBySide(want, got, changes))
+}
+
+// sideBySide draws want, got and a map of the changes between them in three
+// columns
+func sideBySide(want, got *life.Generation, changes []life.CellChange) string {
+	d := want.Dimension()
+	differs := make(map[[2]int]bool, len(changes))
+	for _, c := range changes {
+		differs[[2]int{c.X, c.Y}] = true
+	}
+
+	row := func(g *life.Generation, y int) string {
+		cells := make([]string, d.X)
+		for x := range cells {
+			cells[x] = "."
+			if g.Cells()[x+y*d.X].Alive() {
+				cells[x] = "o"
+			}
+		}
+		return strings.Join(cells, " ")
+	}
+
+	width := max(2*d.X-1, len("want"))
+	var b strings.Builder
+	fmt.Fprintf(&b, "%-*s   %-*s   %s\n", width, "want", width, "got", "diff")
+	for y := 0; y < d.Y; y++ {
+		marks := make([]string, d.X)
+		for x := range marks {
+			marks[x] = "."
+			if differs[[2]int{x, y}] {
+				marks[x] = "x"
+			}
+		}
+		fmt.Fprintf(&b, "%-*s   %-*s   %s\n", width, row(want, y), width, row(got, y), strings.Join(marks, " "))
+	}
+
+	return b.String()
+}
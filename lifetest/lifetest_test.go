@@ -0,0 +1,61 @@
+package lifetest_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/enocom/life"
+	"github.com/enocom/life/lifetest"
+)
+
+// recordingTB captures the errors reported to it
+type recordingTB struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recordingTB) Helper() {}
+
+func (r *recordingTB) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func board(t *testing.T, s string) *life.Generation {
+	t.Helper()
+
+	g, err := life.ParseBoard(s)
+	if err != nil {
+		t.Fatalf("want: no error, got: %v", err)
+	}
+
+	return g
+}
+
+func TestAssertBoardsEqual(t *testing.T) {
+	var tb recordingTB
+	lifetest.AssertBoardsEqual(&tb, board(t, ".o.\n.o.\n"), board(t, ".o.\n.o.\n"))
+	if len(tb.errors) != 0 {
+		t.Errorf("want: no errors for equal boards, got: %v", tb.errors)
+	}
+}
+
+func TestAssertBoardsEqualDiff(t *testing.T) {
+	var tb recordingTB
+	lifetest.AssertBoardsEqual(&tb, board(t, ".o.\n.o.\n"), board(t, "...\noo.\n"))
+
+	want := "boards differ in 2 cells:\n" +
+		"want    got     diff\n" +
+		". o .   . . .   . x .\n" +
+		". o .   o o .   x . .\n"
+	if len(tb.errors) != 1 || tb.errors[0] != want {
+		t.Errorf("want: %#v, got: %#v", want, tb.errors)
+	}
+}
+
+func TestAssertBoardsEqualSize(t *testing.T) {
+	var tb recordingTB
+	lifetest.AssertBoardsEqual(&tb, board(t, "o.\n"), board(t, "o..\n"))
+	if len(tb.errors) != 1 {
+		t.Errorf("want: an error for boards of different sizes, got: %v", tb.errors)
+	}
+}
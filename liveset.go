@@ -0,0 +1,60 @@
+package life
+
+import "fmt"
+
+// LiveSet returns the (x, y) coordinates of every live cell as a set
+func (g *Generation) LiveSet() map[[2]int]struct{} {
+	set := make(map[[2]int]struct{}, g.Population())
+	for i, c := range g.cells {
+		if c.Alive() {
+			set[[2]int{i % g.dimensions.X, i / g.dimensions.X}] = struct{}{}
+		}
+	}
+
+	return set
+}
+
+// LiveCoordinates returns the (x, y) coordinates of every live cell, sorted
+// by y and then by x, so output built from them is the same on every run
+func (g *Generation) LiveCoordinates() [][2]int {
+	coords := make([][2]int, 0, g.Population())
+	// cells are stored row by row, so scanning them in order sorts by y and
+	// then by x
+	for i, c := range g.cells {
+		if c.Alive() {
+			coords = append(coords, [2]int{i % g.dimensions.X, i / g.dimensions.X})
+		}
+	}
+
+	return coords
+}
+
+// FromLiveSet builds a generation of Dimension d with a live cell at each (x,
+// y) coordinate in set. Coordinates outside the board are ignored; use
+// FromLiveSetErr to reject them instead.
+func FromLiveSet(set map[[2]int]struct{}, d Dimension) *Generation {
+	g, _ := fromLiveSet(set, d, false)
+	return g
+}
+
+// FromLiveSetErr is like FromLiveSet but returns an error if any coordinate
+// in set falls outside the board
+func FromLiveSetErr(set map[[2]int]struct{}, d Dimension) (*Generation, error) {
+	return fromLiveSet(set, d, true)
+}
+
+func fromLiveSet(set map[[2]int]struct{}, d Dimension, strict bool) (*Generation, error) {
+	cells := make([]Cell, d.X*d.Y)
+	for p := range set {
+		x, y := p[0], p[1]
+		if x < 0 || x >= d.X || y < 0 || y >= d.Y {
+			if strict {
+				return nil, fmt.Errorf("life: live cell (%d, %d) is outside a %dx%d board", x, y, d.X, d.Y)
+			}
+			continue
+		}
+		cells[x+y*d.X] = NewLiveCell()
+	}
+
+	return NewGeneration(WithDimension(d), WithCells(cells)), nil
+}
@@ -0,0 +1,51 @@
+package life_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/enocom/life"
+)
+
+func TestLiveSetRoundTrip(t *testing.T) {
+	d := life.Dimension{X: 5, Y: 4}
+	g := newBoard(d, [2]int{0, 0}, [2]int{4, 1}, [2]int{2, 3})
+
+	set := g.LiveSet()
+	want := map[[2]int]struct{}{{0, 0}: {}, {4, 1}: {}, {2, 3}: {}}
+	if !reflect.DeepEqual(set, want) {
+		t.Errorf("want: %v, got: %v", want, set)
+	}
+
+	if got := life.FromLiveSet(set, d); !equal(got.Cells(), g.Cells()) {
+		t.Errorf("want: %v, got: %v", g, got)
+	}
+}
+
+func TestFromLiveSetOutside(t *testing.T) {
+	d := life.Dimension{X: 3, Y: 3}
+	set := map[[2]int]struct{}{{1, 1}: {}, {3, 0}: {}, {0, -1}: {}}
+
+	got := life.FromLiveSet(set, d)
+	if want := newBoard(d, [2]int{1, 1}); !equal(got.Cells(), want.Cells()) {
+		t.Errorf("want: %v, got: %v", want, got)
+	}
+
+	if _, err := life.FromLiveSetErr(set, d); err == nil {
+		t.Error("want: an error for coordinates outside the board")
+	}
+}
+
+func TestLiveCoordinates(t *testing.T) {
+	d := life.Dimension{X: 6, Y: 5}
+	g := newBoard(d, [2]int{4, 3}, [2]int{0, 4}, [2]int{5, 0}, [2]int{1, 3}, [2]int{2, 0}, [2]int{3, 2})
+
+	want := [][2]int{{2, 0}, {5, 0}, {3, 2}, {1, 3}, {4, 3}, {0, 4}}
+	if got := g.LiveCoordinates(); !reflect.DeepEqual(got, want) {
+		t.Errorf("want: %v, got: %v", want, got)
+	}
+
+	if got := newBoard(d).LiveCoordinates(); len(got) != 0 {
+		t.Errorf("want: no coordinates for an empty board, got: %v", got)
+	}
+}
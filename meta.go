@@ -0,0 +1,25 @@
+package life
+
+// PatternMeta describes a pattern loaded from or written to a file. In RLE
+// files the name, author and comments are the "#N", "#O" and "#C" lines and
+// the rule is the header's "rule" field. In plaintext files they are the
+// "!Name:", "!Author:" and "!Rule:" lines, with any other "!" line a comment.
+type PatternMeta struct {
+	Name     string
+	Author   string
+	Comments []string
+	Rule     string
+}
+
+// WithMeta attaches a description of the pattern to a generation
+func WithMeta(m PatternMeta) Option {
+	return func(g *Generation) {
+		g.meta = m
+	}
+}
+
+// Meta returns the description of the pattern, which is empty unless the
+// generation was loaded from a file or configured with WithMeta
+func (g *Generation) Meta() PatternMeta {
+	return g.meta
+}
@@ -0,0 +1,143 @@
+package life_test
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/enocom/life"
+)
+
+func TestRLEMetaRoundTrip(t *testing.T) {
+	pattern := "" +
+		"#N Glider\n" +
+		"#O Richard K. Guy\n" +
+		"#C The smallest spaceship.\n" +
+		"x = 3, y = 3, rule = B3/S23\n" +
+		"bo$2bo$3o!\n"
+
+	g, err := life.LoadRLE(strings.NewReader(pattern))
+	if err != nil {
+		t.Fatalf("want: no error, got: %v", err)
+	}
+
+	want := life.PatternMeta{
+		Name:     "Glider",
+		Author:   "Richard K. Guy",
+		Comments: []string{"The smallest spaceship."},
+		Rule:     "B3/S23",
+	}
+	if !reflect.DeepEqual(g.Meta(), want) {
+		t.Errorf("want: %+v, got: %+v", want, g.Meta())
+	}
+
+	var buf bytes.Buffer
+	if err := g.WriteRLE(&buf); err != nil {
+		t.Fatalf("want: no error, got: %v", err)
+	}
+	if buf.String() != pattern {
+		t.Errorf("want: %#v, got: %#v", pattern, buf.String())
+	}
+}
+
+func TestPlaintextMetaRoundTrip(t *testing.T) {
+	pattern := "" +
+		"!Name: Blinker\n" +
+		"!Author: John Conway\n" +
+		"!The smallest oscillator.\n" +
+		"...\n" +
+		"OOO\n" +
+		"...\n"
+
+	g, err := life.LoadPlaintext(strings.NewReader(pattern))
+	if err != nil {
+		t.Fatalf("want: no error, got: %v", err)
+	}
+
+	want := life.PatternMeta{
+		Name:     "Blinker",
+		Author:   "John Conway",
+		Comments: []string{"The smallest oscillator."},
+	}
+	if !reflect.DeepEqual(g.Meta(), want) {
+		t.Errorf("want: %+v, got: %+v", want, g.Meta())
+	}
+
+	var buf bytes.Buffer
+	if err := g.WritePlaintext(&buf); err != nil {
+		t.Fatalf("want: no error, got: %v", err)
+	}
+	if buf.String() != pattern {
+		t.Errorf("want: %#v, got: %#v", pattern, buf.String())
+	}
+}
+
+func TestConvertPreservesMeta(t *testing.T) {
+	rle := "#N Block\n#O Unknown\n#C A still life.\nx = 2, y = 2, rule = B3/S23\n2o$2o!\n"
+
+	g, err := life.LoadRLE(strings.NewReader(rle))
+	if err != nil {
+		t.Fatalf("want: no error, got: %v", err)
+	}
+
+	var cells bytes.Buffer
+	if err := g.WritePlaintext(&cells); err != nil {
+		t.Fatalf("want: no error, got: %v", err)
+	}
+
+	converted, err := life.LoadPlaintext(&cells)
+	if err != nil {
+		t.Fatalf("want: no error, got: %v", err)
+	}
+
+	if !reflect.DeepEqual(converted.Meta(), g.Meta()) {
+		t.Errorf("want: %+v, got: %+v", g.Meta(), converted.Meta())
+	}
+	if !equal(converted.Cells(), g.Cells()) {
+		t.Errorf("want: %v, got: %v", g, converted)
+	}
+}
+
+func TestWriteRLEBlankRowsAndLongLines(t *testing.T) {
+	// - - -
+	// - - -
+	// o - o
+	g := newBoard(life.Dimension{X: 3, Y: 3}, [2]int{0, 2}, [2]int{2, 2})
+
+	var buf bytes.Buffer
+	if err := g.WriteRLE(&buf); err != nil {
+		t.Fatalf("want: no error, got: %v", err)
+	}
+
+	expected := "x = 3, y = 3, rule = B3/S23\n2$obo!\n"
+	if buf.String() != expected {
+		t.Errorf("want: %#v, got: %#v", expected, buf.String())
+	}
+
+	checkerboard := make([]life.Cell, 100)
+	for i := range checkerboard {
+		if i%2 == 0 {
+			checkerboard[i] = life.NewLiveCell()
+		}
+	}
+	wide := life.NewGeneration(
+		life.WithDimension(life.Dimension{X: 100, Y: 1}),
+		life.WithCells(checkerboard),
+	)
+
+	buf.Reset()
+	if err := wide.WriteRLE(&buf); err != nil {
+		t.Fatalf("want: no error, got: %v", err)
+	}
+	for _, line := range strings.Split(buf.String(), "\n") {
+		if len(line) > 70 {
+			t.Errorf("want: lines of at most 70 characters, got: %v", len(line))
+		}
+	}
+
+	loaded, err := life.LoadRLE(&buf)
+	if err != nil || !equal(loaded.Cells(), wide.Cells()) {
+		t.Errorf("want: a round trip, got: %v (err = %v)", loaded, err)
+	}
+}
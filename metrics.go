@@ -0,0 +1,13 @@
+package life
+
+import "time"
+
+// gameMetrics receives the measurements of a running game, for
+// WithMetricsRegistry. A nil gameMetrics is not told anything.
+type gameMetrics interface {
+	// generation records a generation of population live cells which took
+	// took to compute
+	generation(population int, took time.Duration)
+	// event records an event found by the detector
+	event(e Event)
+}
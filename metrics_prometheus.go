@@ -0,0 +1,66 @@
+//go:build prometheus
+
+package life
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// WithMetricsRegistry registers metrics for the game with reg and keeps them
+// up to date as the game runs:
+//
+//	life_population                    gauge of the live cells on the board
+//	life_generations_total             counter of the generations computed
+//	life_generation_duration_seconds   histogram of the time computing each one
+//	life_events_total{kind="cycle"}    counter of the events the game detects,
+//	                                   by kind, as passed to WithNotify
+//
+// The option is only built with the prometheus build tag, which keeps the
+// client library out of builds which do not want it. Like MustRegister, it
+// panics if the metrics are already registered with reg.
+func WithMetricsRegistry(reg *prometheus.Registry) GameOption {
+	m := &prometheusMetrics{
+		population: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "life_population",
+			Help: "The number of live cells on the board.",
+		}),
+		generations: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "life_generations_total",
+			Help: "The number of generations computed.",
+		}),
+		duration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "life_generation_duration_seconds",
+			Help:    "The time taken to compute each generation.",
+			Buckets: prometheus.ExponentialBuckets(1e-6, 4, 10),
+		}),
+		events: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "life_events_total",
+			Help: "The number of events detected, such as cycles, by kind.",
+		}, []string{"kind"}),
+	}
+	reg.MustRegister(m.population, m.generations, m.duration, m.events)
+
+	return func(g *Game) {
+		g.metrics = m
+	}
+}
+
+// prometheusMetrics reports a game's measurements as Prometheus metrics
+type prometheusMetrics struct {
+	population  prometheus.Gauge
+	generations prometheus.Counter
+	duration    prometheus.Histogram
+	events      *prometheus.CounterVec
+}
+
+func (m *prometheusMetrics) generation(population int, took time.Duration) {
+	m.population.Set(float64(population))
+	m.generations.Inc()
+	m.duration.Observe(took.Seconds())
+}
+
+func (m *prometheusMetrics) event(e Event) {
+	m.events.WithLabelValues(e.Kind.String()).Inc()
+}
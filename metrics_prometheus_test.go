@@ -0,0 +1,53 @@
+//go:build prometheus
+
+package life_test
+
+import (
+	"testing"
+
+	"github.com/enocom/life"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestWithMetricsRegistry(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	glider := newBoard(life.Dimension{X: 8, Y: 8},
+		[2]int{1, 0}, [2]int{2, 1}, [2]int{0, 2}, [2]int{1, 2}, [2]int{2, 2},
+	)
+	g := life.NewGame(
+		life.WithUI(life.NopUI{}),
+		life.WithGenerationRate(0),
+		life.WithMaxGenerations(3),
+		life.WithGeneration(glider),
+		life.WithMetricsRegistry(reg),
+	)
+	g.Run()
+
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string]float64)
+	for _, f := range families {
+		m := f.GetMetric()[0]
+		switch f.GetName() {
+		case "life_population":
+			got[f.GetName()] = m.GetGauge().GetValue()
+		case "life_generations_total":
+			got[f.GetName()] = m.GetCounter().GetValue()
+		case "life_generation_duration_seconds":
+			got[f.GetName()] = float64(m.GetHistogram().GetSampleCount())
+		}
+	}
+
+	want := map[string]float64{
+		"life_population":                  5,
+		"life_generations_total":           3,
+		"life_generation_duration_seconds": 3,
+	}
+	for name, w := range want {
+		if got[name] != w {
+			t.Errorf("%s: want: %v, got: %v", name, w, got[name])
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package life
+
+import "io"
+
+// Glyphs drawn by the motion UI, colored with ANSI escape codes
+const (
+	bornGlyph  = "\033[32mo\033[0m"   // green
+	ghostGlyph = "\033[2;31m.\033[0m" // faint red
+)
+
+// NewMotionTerminalUI creates a terminal UI which highlights the latest step:
+// cells born since the previous generation are drawn in green, and cells which
+// just died are left behind for one frame as a faint red ghost. Cells which
+// stay alive are drawn as usual, so the motion of a pattern stands out from
+// its static parts.
+func NewMotionTerminalUI(w io.Writer) UI {
+	return &motionUI{TermUI: NewTerminalUI(w)}
+}
+
+type motionUI struct {
+	*TermUI
+	previous *Generation
+}
+
+// WriteGeneration redraws the screen with the generation, colored by how each
+// cell changed since the last generation drawn
+func (m *motionUI) WriteGeneration(_ int, g *Generation) {
+	frame := g.String()
+	if m.previous != nil && m.previous.dimensions == g.dimensions {
+		frame = g.Render(withGlyphs(motionGlyphs(m.previous, g)))
+	}
+	m.previous = g
+
+	m.ClearScreen()
+	m.Write(frame)
+}
+
+// motionGlyphs returns the glyph for each cell of g given its state in the
+// previous generation, which must have the same dimensions
+func motionGlyphs(previous, g *Generation) []string {
+	glyphs := make([]string, len(g.cells))
+	for i, c := range g.cells {
+		switch was := previous.cells[i].Alive(); {
+		case c.Alive() && !was:
+			glyphs[i] = bornGlyph
+		case !c.Alive() && was:
+			glyphs[i] = ghostGlyph
+		default:
+			glyphs[i] = c.String()
+		}
+	}
+
+	return glyphs
+}
@@ -0,0 +1,33 @@
+package life_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/enocom/life"
+)
+
+func TestMotionTerminalUI(t *testing.T) {
+	var buf strings.Builder
+	ui := life.NewMotionTerminalUI(&buf).(life.GenerationUI)
+
+	blinker := newBoard(life.Dimension{X: 3, Y: 3}, [2]int{0, 1}, [2]int{1, 1}, [2]int{2, 1})
+	ui.WriteGeneration(0, blinker)
+
+	clear := "\033[H\033[2J"
+	if want := clear + blinker.String(); buf.String() != want {
+		t.Errorf("first frame want: %#v, got: %#v", want, buf.String())
+	}
+
+	buf.Reset()
+	ui.WriteGeneration(1, life.Next(blinker))
+
+	born, ghost := "\033[32mo\033[0m", "\033[2;31m.\033[0m"
+	want := clear +
+		"  " + born + "  \n" +
+		ghost + " o " + ghost + "\n" +
+		"  " + born + "  \n"
+	if got := buf.String(); got != want {
+		t.Errorf("second frame want: %#v, got: %#v", want, got)
+	}
+}
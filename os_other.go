@@ -0,0 +1,35 @@
+//go:build !(linux || darwin || dragonfly || freebsd || netbsd || openbsd)
+
+package life
+
+import (
+	"errors"
+	"os"
+)
+
+// terminalSize is unsupported on this platform
+func terminalSize(f *os.File) (cols, rows int, err error) {
+	return 0, 0, errors.New("life: terminal size is unsupported on this platform")
+}
+
+// notifyResize returns a nil channel, which never receives, as resize
+// notifications are unsupported on this platform
+func notifyResize() (<-chan os.Signal, func()) {
+	return nil, func() {}
+}
+
+// notifyPause returns nil channels, which never receive, as pause signals are
+// unsupported on this platform
+func notifyPause() (pause, resume <-chan os.Signal, stop func()) {
+	return nil, nil, func() {}
+}
+
+// makeFIFO is unsupported on this platform, so only an existing pipe can be
+// used
+func makeFIFO(path string) error {
+	if _, err := os.Stat(path); err != nil {
+		return errors.New("life: named pipes are unsupported on this platform")
+	}
+
+	return os.ErrExist
+}
@@ -0,0 +1,62 @@
+//go:build linux || darwin || dragonfly || freebsd || netbsd || openbsd
+
+package life
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+	"unsafe"
+)
+
+// terminalSize returns the number of columns and rows of the terminal f is
+// attached to
+func terminalSize(f *os.File) (cols, rows int, err error) {
+	var ws struct {
+		rows, cols, xpixel, ypixel uint16
+	}
+	_, _, errno := syscall.Syscall(
+		syscall.SYS_IOCTL,
+		f.Fd(),
+		uintptr(syscall.TIOCGWINSZ),
+		uintptr(unsafe.Pointer(&ws)),
+	)
+	if errno != 0 {
+		return 0, 0, errno
+	}
+
+	return int(ws.cols), int(ws.rows), nil
+}
+
+// notifyResize returns a channel which receives a value whenever the terminal
+// is resized, along with a function to stop listening
+func notifyResize() (<-chan os.Signal, func()) {
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, syscall.SIGWINCH)
+
+	return c, func() { signal.Stop(c) }
+}
+
+// notifyPause returns channels which receive SIGUSR1, to pause, and SIGUSR2,
+// to resume, along with a function to stop listening
+func notifyPause() (pause, resume <-chan os.Signal, stop func()) {
+	p := make(chan os.Signal, 1)
+	r := make(chan os.Signal, 1)
+	signal.Notify(p, syscall.SIGUSR1)
+	signal.Notify(r, syscall.SIGUSR2)
+
+	return p, r, func() {
+		signal.Stop(p)
+		signal.Stop(r)
+	}
+}
+
+// makeFIFO creates a named pipe at path
+func makeFIFO(path string) error {
+	err := syscall.Mkfifo(path, 0600)
+	if err != nil {
+		return &os.PathError{Op: "mkfifo", Path: path, Err: err}
+	}
+
+	return nil
+}
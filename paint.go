@@ -0,0 +1,73 @@
+package life
+
+import "fmt"
+
+// Set makes the cell at (x, y) alive or dead, returning an error if the
+// coordinate is not on the board
+func (g *Generation) Set(x, y int, alive bool) error {
+	if err := g.checkOnBoard(x, y); err != nil {
+		return err
+	}
+
+	g.setCell(x+y*g.dimensions.X, Cell{alive: alive})
+
+	return nil
+}
+
+// Paint makes every cell within radius of (x, y), counting diagonal steps as
+// one, alive or dead, so it fills a square 2*radius+1 cells wide centred on
+// the coordinate. The square is clipped to the edges of the board. A radius of
+// zero sets a single cell, as with Set. Paint returns an error if the centre
+// is not on the board or the radius is negative.
+func (g *Generation) Paint(x, y, radius int, alive bool) error {
+	if err := g.checkOnBoard(x, y); err != nil {
+		return err
+	}
+	if radius < 0 {
+		return fmt.Errorf("life: brush radius must not be negative, got %d", radius)
+	}
+
+	for py := max(y-radius, 0); py <= min(y+radius, g.dimensions.Y-1); py++ {
+		for px := max(x-radius, 0); px <= min(x+radius, g.dimensions.X-1); px++ {
+			g.setCell(px+py*g.dimensions.X, Cell{alive: alive})
+		}
+	}
+
+	return nil
+}
+
+// checkOnBoard returns an error if (x, y) is not on the board
+func (g *Generation) checkOnBoard(x, y int) error {
+	if x < 0 || y < 0 || x >= g.dimensions.X || y >= g.dimensions.Y {
+		return fmt.Errorf("life: (%d, %d) is outside the %dx%d board", x, y, g.dimensions.X, g.dimensions.Y)
+	}
+
+	return nil
+}
+
+// Perturbations returns a copy of the generation for each of its cells, in
+// index order, with that one cell toggled between alive and dead. Evolving
+// each copy shows which cells a pattern's behavior depends on. A board of N
+// cells produces N copies of N cells each, so for large boards prefer
+// PerturbationsIn to limit the cells toggled.
+func (g *Generation) Perturbations() []*Generation {
+	return g.PerturbationsIn(Rect{W: g.dimensions.X, H: g.dimensions.Y})
+}
+
+// PerturbationsIn is like Perturbations, but only toggles the cells within r,
+// clipped to the edges of the board
+func (g *Generation) PerturbationsIn(r Rect) []*Generation {
+	r = r.intersect(Rect{W: g.dimensions.X, H: g.dimensions.Y})
+
+	var copies []*Generation
+	for y := r.Y; y < r.Y+r.H; y++ {
+		for x := r.X; x < r.X+r.W; x++ {
+			cells := append([]Cell(nil), g.cells...)
+			idx := x + y*g.dimensions.X
+			cells[idx] = Cell{alive: !cells[idx].Alive()}
+			copies = append(copies, g.successor(cells))
+		}
+	}
+
+	return copies
+}
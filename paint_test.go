@@ -0,0 +1,133 @@
+package life_test
+
+import (
+	"testing"
+
+	"github.com/enocom/life"
+)
+
+func TestSet(t *testing.T) {
+	d := life.Dimension{X: 3, Y: 2}
+	g := newBoard(d, [2]int{0, 0})
+
+	if err := g.Set(2, 1, true); err != nil {
+		t.Fatalf("want: no error, got: %v", err)
+	}
+	if err := g.Set(0, 0, false); err != nil {
+		t.Fatalf("want: no error, got: %v", err)
+	}
+	if want := newBoard(d, [2]int{2, 1}); !equal(g.Cells(), want.Cells()) {
+		t.Errorf("want: %v, got: %v", want, g)
+	}
+
+	if err := g.Set(3, 0, true); err == nil {
+		t.Error("want: an error for a coordinate off the board")
+	}
+}
+
+func TestPaint(t *testing.T) {
+	d := life.Dimension{X: 5, Y: 4}
+	testCases := map[string]struct {
+		x, y, radius int
+		want         [][2]int
+	}{
+		"single cell": {
+			x: 2, y: 1, radius: 0,
+			want: [][2]int{{2, 1}},
+		},
+		"square": {
+			x: 2, y: 1, radius: 1,
+			want: [][2]int{{1, 0}, {2, 0}, {3, 0}, {1, 1}, {2, 1}, {3, 1}, {1, 2}, {2, 2}, {3, 2}},
+		},
+		"clipped at the corner": {
+			x: 0, y: 3, radius: 1,
+			want: [][2]int{{0, 2}, {1, 2}, {0, 3}, {1, 3}},
+		},
+		"larger than the board": {
+			x: 1, y: 1, radius: 10,
+			want: [][2]int{
+				{0, 0}, {1, 0}, {2, 0}, {3, 0}, {4, 0},
+				{0, 1}, {1, 1}, {2, 1}, {3, 1}, {4, 1},
+				{0, 2}, {1, 2}, {2, 2}, {3, 2}, {4, 2},
+				{0, 3}, {1, 3}, {2, 3}, {3, 3}, {4, 3},
+			},
+		},
+	}
+
+	for description, tc := range testCases {
+		g := newBoard(d)
+		if err := g.Paint(tc.x, tc.y, tc.radius, true); err != nil {
+			t.Fatalf("(%s): want: no error, got: %v", description, err)
+		}
+		if want := newBoard(d, tc.want...); !equal(g.Cells(), want.Cells()) {
+			t.Errorf("(%s): want: %v, got: %v", description, want, g)
+		}
+	}
+}
+
+func TestPaintErase(t *testing.T) {
+	d := life.Dimension{X: 3, Y: 3}
+	g := newBoard(d, [2]int{0, 0}, [2]int{1, 1}, [2]int{2, 2})
+
+	if err := g.Paint(2, 2, 1, false); err != nil {
+		t.Fatalf("want: no error, got: %v", err)
+	}
+	if want := newBoard(d, [2]int{0, 0}); !equal(g.Cells(), want.Cells()) {
+		t.Errorf("want: %v, got: %v", want, g)
+	}
+}
+
+func TestPaintErrors(t *testing.T) {
+	g := newBoard(life.Dimension{X: 3, Y: 3})
+
+	testCases := map[string][3]int{
+		"negative radius":  {1, 1, -1},
+		"centre off board": {-1, 1, 1},
+		"centre below":     {1, 3, 0},
+	}
+	for description, tc := range testCases {
+		if err := g.Paint(tc[0], tc[1], tc[2], true); err == nil {
+			t.Errorf("(%s): want an error, got nil", description)
+		}
+	}
+	if !g.IsEmpty() {
+		t.Errorf("want: failed paints to leave the board alone, got: %v", g)
+	}
+}
+
+func TestPerturbations(t *testing.T) {
+	// o .
+	// . .
+	g := newBoard(life.Dimension{X: 2, Y: 2}, [2]int{0, 0})
+
+	got := g.Perturbations()
+	want := []*life.Generation{
+		newBoard(life.Dimension{X: 2, Y: 2}),
+		newBoard(life.Dimension{X: 2, Y: 2}, [2]int{0, 0}, [2]int{1, 0}),
+		newBoard(life.Dimension{X: 2, Y: 2}, [2]int{0, 0}, [2]int{0, 1}),
+		newBoard(life.Dimension{X: 2, Y: 2}, [2]int{0, 0}, [2]int{1, 1}),
+	}
+	if len(got) != len(want) {
+		t.Fatalf("want: %v perturbations, got: %v", len(want), len(got))
+	}
+	for i := range want {
+		if !equal(got[i].Cells(), want[i].Cells()) {
+			t.Errorf("perturbation %d: want: %#v, got: %#v", i, want[i].String(), got[i].String())
+		}
+	}
+	if g.Population() != 1 {
+		t.Errorf("want: the original to be unchanged, got: %#v", g.String())
+	}
+}
+
+func TestPerturbationsIn(t *testing.T) {
+	g := newBoard(life.Dimension{X: 4, Y: 4})
+
+	got := g.PerturbationsIn(life.Rect{X: 3, Y: 2, W: 5, H: 1})
+	if len(got) != 1 {
+		t.Fatalf("want: 1 perturbation inside the board, got: %v", len(got))
+	}
+	if c := got[0].Cells()[3+2*4]; !c.Alive() {
+		t.Errorf("want: the cell at (3, 2) toggled, got: %#v", got[0].String())
+	}
+}
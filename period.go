@@ -0,0 +1,63 @@
+package life
+
+// Period returns the smallest number of generations after which the pattern
+// of live cells on g returns to its starting state under rule, allowing for it
+// to have moved, so a blinker has period 2, a block period 1 and a glider
+// period 4. The pattern evolves for up to maxPeriod generations in otherwise
+// empty space, ignoring the board's edges. The second return value is false
+// when the pattern does not return to its starting state in that time,
+// including when it dies out or settles into something else. An empty board
+// has period 1.
+func Period(g *Generation, rule Rule, maxPeriod int) (int, bool) {
+	box, ok := g.BoundingBox()
+	if !ok {
+		return 1, true
+	}
+	pattern := g.crop(box)
+	pattern.rule = rule
+
+	phases, _ := pattern.phases(maxPeriod)
+	// the cycle begins with the pattern itself only when it is an oscillator
+	// or spaceship rather than something which settles into one
+	if len(phases) == 0 || phases[0] != pattern {
+		return 0, false
+	}
+
+	return len(phases), true
+}
+
+// IsPhoenix reports whether g is a phoenix under rule: an oscillator in which
+// every live cell dies in the next generation, so no cell is ever alive in two
+// generations in a row, yet the pattern carries on. Like Period, the pattern
+// evolves in empty space for up to maxGen generations, and it must itself be
+// one of the phases of its cycle. Still lifes, spaceships and patterns which
+// die out or settle into something else are not phoenixes.
+func IsPhoenix(g *Generation, rule Rule, maxGen int) bool {
+	box, ok := g.BoundingBox()
+	if !ok {
+		return false
+	}
+	pattern := g.crop(box)
+	pattern.rule = rule
+
+	phases, moved := pattern.phases(maxGen)
+	if len(phases) < 2 || phases[0] != pattern || moved {
+		return false
+	}
+
+	// evolve a full period, ending back at the pattern, so every pair of
+	// consecutive phases is compared in place
+	board := pattern.padded(len(phases))
+	board.boundary = NewDeadCell()
+	for i := 0; i < len(phases); i++ {
+		next := Next(board)
+		for j, c := range next.cells {
+			if c.Alive() && board.cells[j].Alive() {
+				return false
+			}
+		}
+		board = next
+	}
+
+	return true
+}
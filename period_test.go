@@ -0,0 +1,134 @@
+package life_test
+
+import (
+	"testing"
+
+	"github.com/enocom/life"
+)
+
+func TestPeriod(t *testing.T) {
+	d := life.Dimension{X: 8, Y: 8}
+	replicator, err := life.ParseRule("B1/S")
+	if err != nil {
+		t.Fatalf("want: no error, got: %v", err)
+	}
+	testCases := map[string]struct {
+		g      *life.Generation
+		rule   life.Rule
+		want   int
+		wantOK bool
+	}{
+		"empty": {
+			g:      newBoard(d),
+			rule:   life.Conway,
+			want:   1,
+			wantOK: true,
+		},
+		"block": {
+			g:      newBoard(d, [2]int{1, 1}, [2]int{2, 1}, [2]int{1, 2}, [2]int{2, 2}),
+			rule:   life.Conway,
+			want:   1,
+			wantOK: true,
+		},
+		"blinker": {
+			g:      newBoard(d, [2]int{1, 6}, [2]int{2, 6}, [2]int{3, 6}),
+			rule:   life.Conway,
+			want:   2,
+			wantOK: true,
+		},
+		"glider": {
+			g:      newBoard(d, [2]int{6, 5}, [2]int{7, 6}, [2]int{5, 7}, [2]int{6, 7}, [2]int{7, 7}),
+			rule:   life.Conway,
+			want:   4,
+			wantOK: true,
+		},
+		"dies out": {
+			g:    newBoard(d, [2]int{1, 1}),
+			rule: life.Conway,
+		},
+		"settles into a block": {
+			g:    newBoard(d, [2]int{1, 1}, [2]int{2, 1}, [2]int{1, 2}),
+			rule: life.Conway,
+		},
+		"blinker under a rule where it grows": {
+			g:    newBoard(d, [2]int{1, 6}, [2]int{2, 6}, [2]int{3, 6}),
+			rule: replicator,
+		},
+	}
+
+	for description, tc := range testCases {
+		got, ok := life.Period(tc.g, tc.rule, 10)
+		if got != tc.want || ok != tc.wantOK {
+			t.Errorf("(%s): want: %v (ok = %v), got: %v (ok = %v)", description, tc.want, tc.wantOK, got, ok)
+		}
+	}
+}
+
+func TestIsPhoenix(t *testing.T) {
+	phoenix, err := life.ParseBoard(`
+....o...
+..o.o...
+......o.
+oo......
+......oo
+.o......
+...o.o..
+...o....
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := life.Dimension{X: 8, Y: 8}
+
+	testCases := map[string]struct {
+		g    *life.Generation
+		want bool
+	}{
+		"phoenix 1":        {g: phoenix, want: true},
+		"empty":            {g: newBoard(d)},
+		"block":            {g: newBoard(d, [2]int{1, 1}, [2]int{2, 1}, [2]int{1, 2}, [2]int{2, 2})},
+		"blinker":          {g: newBoard(d, [2]int{1, 6}, [2]int{2, 6}, [2]int{3, 6})},
+		"glider":           {g: newBoard(d, [2]int{6, 5}, [2]int{7, 6}, [2]int{5, 7}, [2]int{6, 7}, [2]int{7, 7})},
+		"dies out":         {g: newBoard(d, [2]int{3, 3})},
+		"becomes a glider": {g: newBoard(d, [2]int{1, 0}, [2]int{2, 1}, [2]int{0, 2}, [2]int{1, 2}, [2]int{2, 2}, [2]int{6, 6})},
+	}
+
+	for description, tc := range testCases {
+		if got := life.IsPhoenix(tc.g, life.Conway, 10); got != tc.want {
+			t.Errorf("(%s): want: %v, got: %v", description, tc.want, got)
+		}
+	}
+}
+
+func TestPeriodMasked(t *testing.T) {
+	// a mask which covers the whole board changes nothing, however the
+	// pattern is cropped and padded on the way
+	d := life.Dimension{X: 8, Y: 8}
+	g := life.NewGeneration(
+		life.WithDimension(d),
+		life.WithCells(newBoard(d, [2]int{6, 5}, [2]int{7, 6}, [2]int{5, 7}, [2]int{6, 7}, [2]int{7, 7}).Cells()),
+		life.WithMask(func(x, y int) bool { return x < 8 && y < 8 }),
+	)
+
+	if got, ok := life.Period(g, life.Conway, 10); got != 4 || !ok {
+		t.Errorf("want: 4 true, got: %v %v", got, ok)
+	}
+}
+
+func TestPeriodLargeMax(t *testing.T) {
+	// the pattern is given only as much room as it needs, however long it
+	// is allowed to run
+	d := life.Dimension{X: 8, Y: 8}
+	blinker := newBoard(d, [2]int{1, 6}, [2]int{2, 6}, [2]int{3, 6})
+	if got, ok := life.Period(blinker, life.Conway, 1<<20); got != 2 || !ok {
+		t.Errorf("want: 2 true, got: %v %v", got, ok)
+	}
+
+	replicator, err := life.ParseRule("B1/S")
+	if err != nil {
+		t.Fatalf("want: no error, got: %v", err)
+	}
+	if got, ok := life.Period(blinker, replicator, 200); ok {
+		t.Errorf("want: no period for a pattern which keeps growing, got: %v", got)
+	}
+}
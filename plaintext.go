@@ -0,0 +1,182 @@
+package life
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// LoadPlaintext reads a pattern in the plaintext (.cells) format. Lines
+// beginning with '!' are comments, 'O' marks a live cell and '.' a dead one.
+// The board is as wide as the longest line, with shorter lines padded by dead
+// cells. Comments are kept as the generation's PatternMeta, and a "!Rule:"
+// comment sets the generation's rule.
+func LoadPlaintext(r io.Reader) (*Generation, error) {
+	var (
+		rows  [][]Cell
+		width int
+		meta  PatternMeta
+	)
+
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		line := strings.TrimRight(s.Text(), "\r")
+		if strings.HasPrefix(line, "!") {
+			meta.parsePlaintext(strings.TrimPrefix(line, "!"))
+			continue
+		}
+
+		var row []Cell
+		for _, ch := range line {
+			switch ch {
+			case 'O':
+				row = append(row, NewLiveCell())
+			case '.':
+				row = append(row, NewDeadCell())
+			default:
+				return nil, fmt.Errorf("life: unexpected %q in plaintext pattern", ch)
+			}
+		}
+		rows = append(rows, row)
+		width = max(width, len(row))
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+
+	if width == 0 {
+		return nil, errors.New("life: plaintext pattern has no cells")
+	}
+
+	return fromRows(rows, width, meta)
+}
+
+// ParseBoard builds a generation from a picture of the board, one line per
+// row, where 'o', 'O' or '#' marks a live cell and '.' or a space a dead one.
+// As with LoadPlaintext, the board is as wide as the longest line. A single
+// newline at the start and the end of s is ignored, so a raw string literal
+// can begin on the line after its opening quote:
+//
+//	glider, err := life.ParseBoard(`
+//	.o.
+//	..o
+//	ooo
+//	`)
+func ParseBoard(s string) (*Generation, error) {
+	s = strings.TrimPrefix(s, "\n")
+	s = strings.TrimSuffix(s, "\n")
+
+	var (
+		rows  [][]Cell
+		width int
+	)
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimRight(line, "\r")
+
+		var row []Cell
+		for _, ch := range line {
+			switch ch {
+			case 'o', 'O', '#':
+				row = append(row, NewLiveCell())
+			case '.', ' ':
+				row = append(row, NewDeadCell())
+			default:
+				return nil, fmt.Errorf("life: unexpected %q in board", ch)
+			}
+		}
+		rows = append(rows, row)
+		width = max(width, len(row))
+	}
+
+	if width == 0 {
+		return nil, errors.New("life: board has no cells")
+	}
+
+	return fromRows(rows, width, PatternMeta{})
+}
+
+// WritePlaintext writes the generation in the plaintext (.cells) format,
+// including its PatternMeta as comments
+func (g *Generation) WritePlaintext(w io.Writer) error {
+	b := bufio.NewWriter(w)
+
+	m := g.meta
+	if m.Name != "" {
+		fmt.Fprintf(b, "!Name: %s\n", m.Name)
+	}
+	if m.Author != "" {
+		fmt.Fprintf(b, "!Author: %s\n", m.Author)
+	}
+	if m.Rule != "" {
+		fmt.Fprintf(b, "!Rule: %s\n", m.Rule)
+	}
+	for _, c := range m.Comments {
+		fmt.Fprintf(b, "!%s\n", c)
+	}
+
+	for y := 0; y < g.dimensions.Y; y++ {
+		for x := 0; x < g.dimensions.X; x++ {
+			if g.cells[x+y*g.dimensions.X].Alive() {
+				b.WriteByte('O')
+			} else {
+				b.WriteByte('.')
+			}
+		}
+		b.WriteByte('\n')
+	}
+
+	return b.Flush()
+}
+
+// parsePlaintext records a plaintext comment line, without its leading '!'
+func (m *PatternMeta) parsePlaintext(line string) {
+	switch {
+	case strings.HasPrefix(line, "Name:"):
+		m.Name = strings.TrimSpace(strings.TrimPrefix(line, "Name:"))
+	case strings.HasPrefix(line, "Author:"):
+		m.Author = strings.TrimSpace(strings.TrimPrefix(line, "Author:"))
+	case strings.HasPrefix(line, "Rule:"):
+		m.Rule = strings.TrimSpace(strings.TrimPrefix(line, "Rule:"))
+	default:
+		m.Comments = append(m.Comments, line)
+	}
+}
+
+// fromRows builds a pattern from rows of cells, padding each row with dead
+// cells to width
+func fromRows(rows [][]Cell, width int, meta PatternMeta) (*Generation, error) {
+	cells := make([]Cell, 0, width*len(rows))
+	for _, row := range rows {
+		cells = append(cells, row...)
+		cells = append(cells, make([]Cell, width-len(row))...)
+	}
+
+	return newPattern(Dimension{X: width, Y: len(rows)}, cells, meta)
+}
+
+// newPattern builds a generation loaded from a file, following the rule named
+// by its metadata
+func newPattern(d Dimension, cells []Cell, meta PatternMeta) (*Generation, error) {
+	rule := Conway
+	if meta.Rule != "" {
+		var err error
+		if rule, err = ParseRule(meta.Rule); err != nil {
+			return nil, err
+		}
+	}
+	for _, c := range cells {
+		if c.State() >= rule.States() {
+			return nil, fmt.Errorf("life: pattern has a cell in state %d, but rule %q has %d states",
+				c.State(), meta.Rule, rule.States())
+		}
+	}
+
+	return NewGeneration(
+		WithDimension(d),
+		WithCells(cells),
+		WithMeta(meta),
+		WithRule(rule),
+	), nil
+}
@@ -0,0 +1,74 @@
+package life_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/enocom/life"
+)
+
+func TestLoadPlaintext(t *testing.T) {
+	pattern := "!Name: Glider\n!\n.O\n..O\r\nOOO\n"
+
+	g, err := life.LoadPlaintext(strings.NewReader(pattern))
+	if err != nil {
+		t.Fatalf("want: no error, got: %v", err)
+	}
+
+	want := newBoard(life.Dimension{X: 3, Y: 3},
+		[2]int{1, 0}, [2]int{2, 1}, [2]int{0, 2}, [2]int{1, 2}, [2]int{2, 2},
+	)
+	if g.Dimension() != want.Dimension() || !equal(g.Cells(), want.Cells()) {
+		t.Errorf("want: %v, got: %v", want, g)
+	}
+}
+
+func TestLoadPlaintextErrors(t *testing.T) {
+	testCases := map[string]string{
+		"empty":         "",
+		"only comments": "!Name: nothing\n",
+		"bad character": ".O\nx.\n",
+	}
+
+	for description, pattern := range testCases {
+		if _, err := life.LoadPlaintext(strings.NewReader(pattern)); err == nil {
+			t.Errorf("(%s): want an error, got nil", description)
+		}
+	}
+}
+
+func TestParseBoard(t *testing.T) {
+	glider := newBoard(life.Dimension{X: 3, Y: 3},
+		[2]int{1, 0}, [2]int{2, 1}, [2]int{0, 2}, [2]int{1, 2}, [2]int{2, 2},
+	)
+
+	testCases := map[string]string{
+		"dots":               ".o.\n..o\nooo\n",
+		"hashes and spaces":  " #\n  #\n###",
+		"raw string literal": "\n.O.\n..O\nOOO\n",
+	}
+
+	for description, board := range testCases {
+		g, err := life.ParseBoard(board)
+		if err != nil {
+			t.Fatalf("(%s): want: no error, got: %v", description, err)
+		}
+		if g.Dimension() != glider.Dimension() || !equal(g.Cells(), glider.Cells()) {
+			t.Errorf("(%s): want: %v, got: %v", description, glider, g)
+		}
+	}
+}
+
+func TestParseBoardErrors(t *testing.T) {
+	testCases := map[string]string{
+		"empty":         "",
+		"only newlines": "\n\n",
+		"bad character": ".o\nx.\n",
+	}
+
+	for description, board := range testCases {
+		if _, err := life.ParseBoard(board); err == nil {
+			t.Errorf("(%s): want an error, got nil", description)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package life_test
+
+import (
+	"bytes"
+	"image/png"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/enocom/life"
+)
+
+func TestWritePNG(t *testing.T) {
+	g := newBoard(life.Dimension{X: 3, Y: 2}, [2]int{1, 0}, [2]int{2, 1})
+
+	var buf bytes.Buffer
+	if err := g.WritePNG(&buf, 2); err != nil {
+		t.Fatalf("want: no error, got: %v", err)
+	}
+
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("want: a valid PNG, got: %v", err)
+	}
+	if b := img.Bounds(); b.Dx() != 6 || b.Dy() != 4 {
+		t.Errorf("want: 6x4 image, got: %vx%v", b.Dx(), b.Dy())
+	}
+
+	testCases := map[[2]int]bool{
+		{0, 0}: false, {2, 0}: true, {3, 1}: true, {4, 2}: true, {5, 3}: true, {1, 3}: false,
+	}
+	for p, alive := range testCases {
+		r, _, _, _ := img.At(p[0], p[1]).RGBA()
+		if got := r > 0; got != alive {
+			t.Errorf("pixel %v: want alive: %v, got: %v", p, alive, got)
+		}
+	}
+
+	if err := g.WritePNG(&buf, 0); err == nil {
+		t.Error("want: an error for a zero cell size")
+	}
+}
+
+func TestWritePNGCellHeight(t *testing.T) {
+	g := newBoard(life.Dimension{X: 3, Y: 2}, [2]int{1, 0})
+
+	var buf bytes.Buffer
+	if err := g.WritePNG(&buf, 2, life.WithCellHeight(4)); err != nil {
+		t.Fatalf("want: no error, got: %v", err)
+	}
+
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("want: a valid PNG, got: %v", err)
+	}
+	if b := img.Bounds(); b.Dx() != 6 || b.Dy() != 8 {
+		t.Errorf("want: 6x8 image, got: %vx%v", b.Dx(), b.Dy())
+	}
+	if r, _, _, _ := img.At(3, 3).RGBA(); r == 0 {
+		t.Error("want: the live cell to cover its full height")
+	}
+	if r, _, _, _ := img.At(3, 4).RGBA(); r != 0 {
+		t.Error("want: the cell below to be dead")
+	}
+
+	if err := g.WritePNG(&buf, 2, life.WithCellHeight(0)); err == nil {
+		t.Error("want: an error for a zero cell height")
+	}
+}
+
+func TestPNGSequenceRecorder(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "frames")
+	ui, err := life.NewPNGSequenceRecorder(dir, 1, 2)
+	if err != nil {
+		t.Fatalf("want: no error, got: %v", err)
+	}
+
+	g := life.NewGame(
+		life.WithUI(ui),
+		life.WithGenerationRate(0),
+		life.WithMaxGenerations(5),
+	)
+	g.Run()
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("want: frames directory, got: %v", err)
+	}
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+
+	want := []string{"frame_000000.png", "frame_000002.png", "frame_000004.png"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("want: %v, got: %v", want, names)
+	}
+}
+
+func TestPNGSequenceRecorderLogsFailures(t *testing.T) {
+	dir := t.TempDir()
+	// a directory in the way of the first frame stops it being written
+	if err := os.Mkdir(filepath.Join(dir, "frame_000000.png"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	ui, err := life.NewPNGSequenceRecorder(dir, 1, 1)
+	if err != nil {
+		t.Fatalf("want: no error, got: %v", err)
+	}
+
+	var logs bytes.Buffer
+	life.NewGame(
+		life.WithUI(ui),
+		life.WithGenerationRate(0),
+		life.WithMaxGenerations(1),
+		life.WithLogger(log.New(&logs, "", 0)),
+	).Run()
+
+	if !strings.Contains(logs.String(), "recording generation 0") {
+		t.Errorf("want: the failed frame in the game's log, got: %#v", logs.String())
+	}
+}
+
+func TestPNGSequenceRecorderInvalid(t *testing.T) {
+	if _, err := life.NewPNGSequenceRecorder(t.TempDir(), 1, 0); err == nil {
+		t.Error("want: an error for recording every 0 generations")
+	}
+	if _, err := life.NewPNGSequenceRecorder(t.TempDir(), 0, 1); err == nil {
+		t.Error("want: an error for a zero cell size")
+	}
+}
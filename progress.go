@@ -0,0 +1,40 @@
+package life
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// progressInterval is the minimum time between two progress updates
+const progressInterval = 250 * time.Millisecond
+
+func newProgress(w io.Writer) *progress {
+	return &progress{w: w}
+}
+
+// progress throttles progress updates for a running game
+type progress struct {
+	w    io.Writer
+	last time.Time
+}
+
+// report writes the progress for generation n of total if enough time has
+// passed since the previous update or n is the final generation. A total of
+// zero means the run is unbounded. A nil progress reports nothing.
+func (p *progress) report(n, total int) {
+	if p == nil {
+		return
+	}
+
+	if n != total && time.Since(p.last) < progressInterval {
+		return
+	}
+	p.last = time.Now()
+
+	if total == 0 {
+		fmt.Fprintf(p.w, "generation %d\n", n)
+		return
+	}
+	fmt.Fprintf(p.w, "generation %d/%d (%d%%)\n", n, total, n*100/total)
+}
@@ -0,0 +1,120 @@
+package life
+
+import (
+	"log"
+	"math/rand"
+	"time"
+)
+
+// WithRandomRegion configures a generation to be seeded randomly within the
+// rectangle from (minX, minY) to (maxX, maxY) inclusive, leaving every other
+// cell dead. Each cell in the region is alive with probability density.
+// Coordinates outside the board are clamped to its edges. When a game builds
+// the board, a warning about the clamping is logged to the logger given to it
+// by WithLogger.
+func WithRandomRegion(minX, minY, maxX, maxY int, density float64) Option {
+	return func(g *Generation) {
+		g.generator = &regionCellGenerator{
+			d:       &g.dimensions,
+			region:  Rect{X: minX, Y: minY, W: maxX - minX + 1, H: maxY - minY + 1},
+			density: density,
+			r:       rand.New(rand.NewSource(time.Now().UnixNano())),
+		}
+	}
+}
+
+// regionCellGenerator reads the dimensions of the generation it seeds when
+// generating, so that it works regardless of the order options are applied
+type regionCellGenerator struct {
+	d       *Dimension
+	region  Rect
+	density float64
+	r       *rand.Rand
+	nextIdx int
+	logger  *log.Logger
+}
+
+// setLogger sets where the clamping warning is logged
+func (g *regionCellGenerator) setLogger(l *log.Logger) {
+	g.logger = l
+}
+
+func (g *regionCellGenerator) Generate() Cell {
+	if g.nextIdx == 0 {
+		g.clamp()
+	}
+	x, y := g.nextIdx%g.d.X, g.nextIdx/g.d.X
+	g.nextIdx++
+
+	if !g.region.contains(x, y) || g.r.Float64() >= g.density {
+		return NewDeadCell()
+	}
+
+	return NewLiveCell()
+}
+
+// clamp restricts the region to the board, warning when it had to
+func (g *regionCellGenerator) clamp() {
+	clamped := g.region.intersect(Rect{W: g.d.X, H: g.d.Y})
+	if clamped != g.region && g.logger != nil {
+		g.logger.Printf("life: random region %+v clamped to board as %+v", g.region, clamped)
+	}
+	g.region = clamped
+}
+
+// contains reports whether (x, y) lies within r
+func (r Rect) contains(x, y int) bool {
+	return x >= r.X && x < r.X+r.W && y >= r.Y && y < r.Y+r.H
+}
+
+// intersect returns the overlap of r and o, which is empty when they do not
+// overlap
+func (r Rect) intersect(o Rect) Rect {
+	minX, minY := max(r.X, o.X), max(r.Y, o.Y)
+	maxX, maxY := min(r.X+r.W, o.X+o.W), min(r.Y+r.H, o.Y+o.H)
+	if maxX <= minX || maxY <= minY {
+		return Rect{}
+	}
+
+	return Rect{X: minX, Y: minY, W: maxX - minX, H: maxY - minY}
+}
+
+// Region pairs an area of the board with the generator which seeds it, for
+// NewGenerationComposite
+type Region struct {
+	Rect      Rect
+	Generator CellGenerator
+}
+
+// NewGenerationComposite returns a generation of dimension d seeded region by
+// region, e.g. dense random cells in one corner and a fixed pattern in
+// another. Each region's generator produces the cells of its rectangle in
+// reading order, and a PositionalCellGenerator is given positions relative to
+// the rectangle's top left corner, so a fixed pattern is stamped the same way
+// wherever its region lies. Parts of a region beyond the board are generated
+// but discarded. Where regions overlap the last one wins, and cells outside
+// every region are dead. Further options, such as the rule, configure the
+// generation as with NewGeneration.
+func NewGenerationComposite(d Dimension, regions []Region, opts ...Option) *Generation {
+	cells := make([]Cell, d.X*d.Y)
+	board := Rect{W: d.X, H: d.Y}
+	for _, r := range regions {
+		positional, _ := r.Generator.(PositionalCellGenerator)
+		for y := 0; y < r.Rect.H; y++ {
+			for x := 0; x < r.Rect.W; x++ {
+				var c Cell
+				if positional != nil {
+					c = positional.GenerateAt(x, y)
+				} else {
+					c = r.Generator.Generate()
+				}
+
+				if bx, by := r.Rect.X+x, r.Rect.Y+y; board.contains(bx, by) {
+					cells[bx+by*d.X] = c
+				}
+			}
+		}
+	}
+
+	return NewGeneration(append(opts, WithDimension(d), WithCells(cells))...)
+}
@@ -0,0 +1,112 @@
+package life_test
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/enocom/life"
+)
+
+func TestWithRandomRegion(t *testing.T) {
+	g := life.NewGeneration(
+		life.WithDimension(life.Dimension{X: 6, Y: 6}),
+		life.WithRandomRegion(1, 2, 3, 4, 1.0),
+	)
+
+	got, ok := g.BoundingBox()
+	want := life.Rect{X: 1, Y: 2, W: 3, H: 3}
+	if !ok || got != want {
+		t.Errorf("want: %+v, got: %+v", want, got)
+	}
+
+	live := 0
+	for _, c := range g.Cells() {
+		if c.Alive() {
+			live++
+		}
+	}
+	if live != 9 {
+		t.Errorf("want: 9 live cells, got: %v", live)
+	}
+}
+
+func TestWithRandomRegionEmptyDensity(t *testing.T) {
+	g := life.NewGeneration(
+		life.WithDimension(life.Dimension{X: 4, Y: 4}),
+		life.WithRandomRegion(0, 0, 3, 3, 0),
+	)
+
+	if _, ok := g.BoundingBox(); ok {
+		t.Error("want: no live cells at zero density")
+	}
+}
+
+func TestWithRandomRegionClamps(t *testing.T) {
+	var global bytes.Buffer
+	log.SetOutput(&global)
+	defer log.SetOutput(os.Stderr)
+
+	g := life.NewGeneration(
+		life.WithRandomRegion(-2, 1, 10, 10, 1.0),
+		life.WithDimension(life.Dimension{X: 4, Y: 4}),
+	)
+
+	got, _ := g.BoundingBox()
+	want := life.Rect{X: 0, Y: 1, W: 4, H: 3}
+	if got != want {
+		t.Errorf("want: %+v, got: %+v", want, got)
+	}
+
+	var logs bytes.Buffer
+	life.NewGame(
+		life.WithUI(nil),
+		life.WithBoardSize(4),
+		life.WithGenerationRate(0),
+		life.WithMaxGenerations(1),
+		life.WithGenerationOptions(life.WithRandomRegion(-2, 1, 10, 10, 1.0)),
+		life.WithLogger(log.New(&logs, "", 0)),
+	).Run()
+
+	if !strings.Contains(logs.String(), "clamped") {
+		t.Errorf("want: a clamping warning in the game's log, got: %#v", logs.String())
+	}
+	if global.Len() != 0 {
+		t.Errorf("want: nothing in the standard log, got: %#v", global.String())
+	}
+}
+
+func TestNewGenerationComposite(t *testing.T) {
+	live := func(n int) []life.Cell {
+		cells := make([]life.Cell, n)
+		for i := range cells {
+			cells[i] = life.NewLiveCell()
+		}
+		return cells
+	}
+	glider := newBoard(life.Dimension{X: 3, Y: 3},
+		[2]int{1, 0}, [2]int{2, 1}, [2]int{0, 2}, [2]int{1, 2}, [2]int{2, 2},
+	)
+
+	g := life.NewGenerationComposite(life.Dimension{X: 6, Y: 4}, []life.Region{
+		// a full 2x2 square in the top left, partly overwritten below
+		{Rect: life.Rect{X: 0, Y: 0, W: 2, H: 2}, Generator: life.NewFixedCellGenerator(live(4))},
+		{Rect: life.Rect{X: 1, Y: 1, W: 1, H: 1}, Generator: life.NewFixedCellGenerator([]life.Cell{life.NewDeadCell()})},
+		// a glider hanging off the bottom right corner
+		{Rect: life.Rect{X: 4, Y: 2, W: 3, H: 3}, Generator: life.NewFixedCellGenerator(glider.Cells())},
+	}, life.WithRule(mustParseRule(t, "B36/S23")))
+
+	// o o . . . .
+	// o . . . . .
+	// . . . . . o
+	// . . . . . .
+	want := newBoard(life.Dimension{X: 6, Y: 4}, [2]int{0, 0}, [2]int{1, 0}, [2]int{0, 1}, [2]int{5, 2})
+	if !equal(g.Cells(), want.Cells()) {
+		t.Errorf("want: %#v, got: %#v", want.String(), g.String())
+	}
+	if got := g.Rule().String(); got != "B36/S23" {
+		t.Errorf("want: the options applied to the generation, got rule %v", got)
+	}
+}
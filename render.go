@@ -0,0 +1,218 @@
+package life
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// RenderOption configures how a generation is drawn as text
+type RenderOption func(*renderer)
+
+// WithAxes labels the rendered board with the index of each row down the left
+// edge and the index of each column across the top. Column indices of more
+// than one digit are written vertically, one digit per header line, so they
+// stay aligned with their cells.
+func WithAxes() RenderOption {
+	return func(r *renderer) {
+		r.axes = true
+	}
+}
+
+// WithAliveGlyph draws live cells as glyph instead of "o"
+func WithAliveGlyph(glyph string) RenderOption {
+	return func(r *renderer) {
+		r.alive = glyph
+	}
+}
+
+// WithDeadGlyph draws dead cells as glyph instead of a space. A visible glyph
+// such as "." keeps the structure of the grid unambiguous when the board is
+// shared as text.
+func WithDeadGlyph(glyph string) RenderOption {
+	return func(r *renderer) {
+		r.dead = glyph
+	}
+}
+
+// WithInvertedDisplay swaps the glyphs of live and dead cells, drawing dead
+// cells with the live glyph on a blank background of live cells. Only the
+// drawing changes; unlike Invert, the cells themselves are left as they are.
+func WithInvertedDisplay() RenderOption {
+	return func(r *renderer) {
+		r.inverted = true
+	}
+}
+
+// WithWrapIndicator surrounds a board whose edges wrap, as configured by
+// WithWrap, with a border of "~" as a reminder that patterns leaving one edge
+// return on the opposite one. Boards which do not wrap are drawn without a
+// border.
+func WithWrapIndicator() RenderOption {
+	return func(r *renderer) {
+		r.wrapIndicator = true
+	}
+}
+
+// WithViewport draws only the w by h cells with their top left corner at (x,
+// y), leaving the rest of the board out of the frame, so one area of a large
+// board can be watched. Parts of the viewport beyond the board are clamped to
+// its edges. Axes label the cells with their positions on the whole board.
+func WithViewport(x, y, w, h int) RenderOption {
+	return func(r *renderer) {
+		r.viewport = &Rect{X: x, Y: y, W: w, H: h}
+	}
+}
+
+// WithCellRenderer draws each cell as the string draw returns for it, given
+// the cell and its position on the board, so drawing can depend on more than
+// whether the cell is alive: a colour gradient by column, say, or different
+// glyphs in different regions. draw is used in place of the glyphs set by
+// WithAliveGlyph, WithDeadGlyph and WithInvertedDisplay. Without it cells are
+// drawn with those glyphs. Pass it to a game with WithRenderOptions. Axes
+// assume each cell is drawn one column wide.
+func WithCellRenderer(draw func(c Cell, x, y int) string) RenderOption {
+	return func(r *renderer) {
+		r.cellRenderer = draw
+	}
+}
+
+// withGlyphs draws the cell at each index as the glyph at the same index,
+// regardless of its state
+func withGlyphs(glyphs []string) RenderOption {
+	return func(r *renderer) {
+		r.glyphs = glyphs
+	}
+}
+
+type renderer struct {
+	axes          bool
+	alive         string
+	dead          string
+	glyphs        []string
+	inverted      bool
+	annotations   bool
+	wrapIndicator bool
+	viewport      *Rect
+	cellRenderer  func(c Cell, x, y int) string
+}
+
+// wrapBorder is drawn around the edges of a board which wraps
+const wrapBorder = "~"
+
+// glyph returns how c, at index idx and position (x, y), is drawn
+func (r *renderer) glyph(idx, x, y int, c Cell) string {
+	if r.cellRenderer != nil {
+		return r.cellRenderer(c, x, y)
+	}
+	if r.glyphs != nil {
+		return r.glyphs[idx]
+	}
+
+	if c.Alive() != r.inverted {
+		return r.alive
+	}
+
+	return r.dead
+}
+
+// Render returns a representation of Generation drawn according to opts.
+// Without options it is the same as String.
+func (g *Generation) Render(opts ...RenderOption) string {
+	r := renderer{
+		alive: NewLiveCell().String(),
+		dead:  NewDeadCell().String(),
+	}
+	for _, o := range opts {
+		o(&r)
+	}
+
+	view := Rect{W: g.dimensions.X, H: g.dimensions.Y}
+	if r.viewport != nil {
+		view = r.viewport.intersect(view)
+	}
+
+	var b strings.Builder
+	border := r.wrapIndicator && g.wrap
+	labelWidth, indent := 0, 0
+	if r.axes {
+		labelWidth = len(strconv.Itoa(max(view.Y+view.H-1, 0)))
+		indent = labelWidth + 1
+		if border {
+			writeColumnLabels(&b, view.X, view.W, indent+len(wrapBorder)+1)
+		} else {
+			writeColumnLabels(&b, view.X, view.W, indent)
+		}
+	}
+
+	rows := make([]string, view.H)
+	for i := range rows {
+		row := view.Y + i
+		var line strings.Builder
+		if g.neighborhood == Hexagonal && row%2 == 1 {
+			line.WriteString(" ")
+		}
+		for column := view.X; column < view.X+view.W; column++ {
+			if column > view.X {
+				line.WriteString(" ")
+			}
+			idx := column + row*g.dimensions.X
+			line.WriteString(r.glyph(idx, column, row, g.cells[idx]))
+		}
+		rows[i] = line.String()
+	}
+	if r.annotations {
+		g.annotate(rows, view)
+	}
+
+	width := 0
+	for _, row := range rows {
+		width = max(width, utf8.RuneCountInString(row))
+	}
+
+	borderLine := strings.Repeat(" ", indent) +
+		strings.Repeat(wrapBorder, width+2*(len(wrapBorder)+1)) + "\n"
+	if border {
+		b.WriteString(borderLine)
+	}
+	for i, row := range rows {
+		if r.axes {
+			fmt.Fprintf(&b, "%*d ", labelWidth, view.Y+i)
+		}
+		if border {
+			pad := strings.Repeat(" ", width-utf8.RuneCountInString(row))
+			row = wrapBorder + " " + row + pad + " " + wrapBorder
+		}
+		b.WriteString(row)
+		b.WriteString("\n")
+	}
+	if border {
+		b.WriteString(borderLine)
+	}
+
+	return b.String()
+}
+
+// writeColumnLabels writes the index of each of n columns above the board,
+// beginning with first, one line per digit with the most significant first,
+// after indent spaces. With no columns there is nothing to label.
+func writeColumnLabels(b *strings.Builder, first, n, indent int) {
+	if n <= 0 {
+		return
+	}
+	width := len(strconv.Itoa(max(first+n-1, 0)))
+	for digit := 0; digit < width; digit++ {
+		b.WriteString(strings.Repeat(" ", indent))
+		for column := first; column < first+n; column++ {
+			label := fmt.Sprintf("%*d", width, column)
+			b.WriteByte(label[digit])
+
+			if column == first+n-1 {
+				b.WriteString("\n")
+			} else {
+				b.WriteString(" ")
+			}
+		}
+	}
+}
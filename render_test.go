@@ -0,0 +1,217 @@
+package life_test
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/enocom/life"
+)
+
+func TestRenderWithAxes(t *testing.T) {
+	g := newBoard(life.Dimension{X: 3, Y: 2}, [2]int{0, 0}, [2]int{2, 1})
+
+	display := g.Render(life.WithAxes())
+	expected := "" +
+		"  0 1 2\n" +
+		"0 o    \n" +
+		"1     o\n"
+	if display != expected {
+		t.Errorf("want: %#v, got: %#v", expected, display)
+	}
+}
+
+func TestRenderWithAxesMultipleDigits(t *testing.T) {
+	g := newBoard(life.Dimension{X: 12, Y: 11}, [2]int{11, 10})
+
+	lines := strings.Split(g.Render(life.WithAxes()), "\n")
+
+	// two header lines for the two-digit column indices
+	wantTens := "                       1 1"
+	wantOnes := "   0 1 2 3 4 5 6 7 8 9 0 1"
+	if lines[0] != wantTens || lines[1] != wantOnes {
+		t.Errorf("want: %#v and %#v, got: %#v and %#v", wantTens, wantOnes, lines[0], lines[1])
+	}
+
+	// row labels are right aligned to the widest index
+	if !strings.HasPrefix(lines[2], " 0 ") || !strings.HasPrefix(lines[12], "10 ") {
+		t.Errorf("want: aligned row labels, got: %#v and %#v", lines[2], lines[12])
+	}
+	if !strings.HasSuffix(lines[12], "o") {
+		t.Errorf("want: the live cell under column 11, got: %#v", lines[12])
+	}
+}
+
+func TestRenderWithAxesNoColumns(t *testing.T) {
+	g := newBoard(life.Dimension{X: 3, Y: 2}, [2]int{0, 0})
+
+	if display := g.Render(life.WithAxes(), life.WithViewport(1, 0, 0, 2)); display != "" {
+		t.Errorf("want: nothing drawn for an empty view, got: %#v", display)
+	}
+}
+
+func TestRenderDefault(t *testing.T) {
+	g := newBoard(life.Dimension{X: 2, Y: 2}, [2]int{0, 0}, [2]int{1, 1})
+
+	if g.Render() != g.String() {
+		t.Errorf("want: %#v, got: %#v", g.String(), g.Render())
+	}
+}
+
+func TestRenderWithGlyphs(t *testing.T) {
+	g := newBoard(life.Dimension{X: 2, Y: 2}, [2]int{0, 0}, [2]int{1, 1})
+
+	testCases := map[string]struct {
+		opts []life.RenderOption
+		want string
+	}{
+		"dead":  {opts: []life.RenderOption{life.WithDeadGlyph(".")}, want: "o .\n. o\n"},
+		"alive": {opts: []life.RenderOption{life.WithAliveGlyph("#")}, want: "#  \n  #\n"},
+		"both": {
+			opts: []life.RenderOption{life.WithAliveGlyph("#"), life.WithDeadGlyph(".")},
+			want: "# .\n. #\n",
+		},
+		"inverted": {opts: []life.RenderOption{life.WithInvertedDisplay()}, want: "  o\no  \n"},
+		"inverted with glyphs": {
+			opts: []life.RenderOption{life.WithInvertedDisplay(), life.WithAliveGlyph("#"), life.WithDeadGlyph(".")},
+			want: ". #\n# .\n",
+		},
+	}
+
+	for description, tc := range testCases {
+		if display := g.Render(tc.opts...); display != tc.want {
+			t.Errorf("(%s): want %#v, got %#v", description, tc.want, display)
+		}
+	}
+}
+
+func TestRenderWithCellRenderer(t *testing.T) {
+	g := newBoard(life.Dimension{X: 3, Y: 2}, [2]int{0, 0}, [2]int{2, 1})
+
+	// live cells by column, dead cells by row
+	byPosition := life.WithCellRenderer(func(c life.Cell, x, y int) string {
+		if c.Alive() {
+			return string(rune('a' + x))
+		}
+		return strconv.Itoa(y)
+	})
+
+	if got, want := g.Render(byPosition), "a 0 0\n1 1 c\n"; got != want {
+		t.Errorf("want %#v, got %#v", want, got)
+	}
+
+	// the glyph options give way to the cell renderer
+	got := g.Render(byPosition, life.WithInvertedDisplay(), life.WithAliveGlyph("#"))
+	if want := "a 0 0\n1 1 c\n"; got != want {
+		t.Errorf("want %#v, got %#v", want, got)
+	}
+
+	// positions are those on the whole board, not the viewport
+	if got, want := g.Render(byPosition, life.WithViewport(2, 0, 1, 2)), "0\nc\n"; got != want {
+		t.Errorf("want %#v, got %#v", want, got)
+	}
+
+	plain := life.WithCellRenderer(func(c life.Cell, x, y int) string { return c.String() })
+	if got := g.Render(plain); got != g.String() {
+		t.Errorf("want: the default output from Cell.String, got %#v", got)
+	}
+}
+
+func TestRenderWithWrapIndicator(t *testing.T) {
+	cells := newBoard(life.Dimension{X: 3, Y: 2}, [2]int{0, 0}, [2]int{2, 1}).Cells()
+	wrapped := life.NewGeneration(
+		life.WithDimension(life.Dimension{X: 3, Y: 2}),
+		life.WithCells(cells),
+		life.WithWrap(),
+	)
+
+	display := wrapped.Render(life.WithWrapIndicator())
+	expected := "" +
+		"~~~~~~~~~\n" +
+		"~ o     ~\n" +
+		"~     o ~\n" +
+		"~~~~~~~~~\n"
+	if display != expected {
+		t.Errorf("want: %#v, got: %#v", expected, display)
+	}
+
+	display = wrapped.Render(life.WithWrapIndicator(), life.WithAxes())
+	expected = "" +
+		"    0 1 2\n" +
+		"  ~~~~~~~~~\n" +
+		"0 ~ o     ~\n" +
+		"1 ~     o ~\n" +
+		"  ~~~~~~~~~\n"
+	if display != expected {
+		t.Errorf("want: %#v, got: %#v", expected, display)
+	}
+
+	plain := life.NewGeneration(life.WithDimension(life.Dimension{X: 3, Y: 2}), life.WithCells(cells))
+	if got, want := plain.Render(life.WithWrapIndicator()), plain.String(); got != want {
+		t.Errorf("want: no border on a board which does not wrap, got: %#v", got)
+	}
+}
+
+func TestRenderWithViewport(t *testing.T) {
+	g := newBoard(life.Dimension{X: 5, Y: 4}, [2]int{0, 0}, [2]int{2, 1}, [2]int{3, 2}, [2]int{4, 3})
+
+	testCases := map[string]struct {
+		opts []life.RenderOption
+		want string
+	}{
+		"inside the board": {
+			opts: []life.RenderOption{life.WithViewport(2, 1, 2, 2), life.WithDeadGlyph(".")},
+			want: "o .\n. o\n",
+		},
+		"clamped to the board": {
+			opts: []life.RenderOption{life.WithViewport(3, 2, 10, 10), life.WithDeadGlyph(".")},
+			want: "o .\n. o\n",
+		},
+		"with axes": {
+			opts: []life.RenderOption{life.WithViewport(2, 1, 2, 2), life.WithAxes()},
+			want: "" +
+				"  2 3\n" +
+				"1 o  \n" +
+				"2   o\n",
+		},
+		"off the board": {
+			opts: []life.RenderOption{life.WithViewport(7, 7, 2, 2)},
+			want: "",
+		},
+	}
+
+	for description, tc := range testCases {
+		if got := g.Render(tc.opts...); got != tc.want {
+			t.Errorf("(%s): want: %#v, got: %#v", description, tc.want, got)
+		}
+	}
+}
+
+func TestRenderWithAnnotations(t *testing.T) {
+	g, err := life.ParseBoard(`
+oo........
+oo........
+..........
+..........
+......ooo.
+..........
+.o........
+.o.o......
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "" +
+		"o o                \n" +
+		"o o                \n" +
+		"block              \n" +
+		"            blinker\n" +
+		"            o o o  \n" +
+		"                   \n" +
+		"  o                \n" +
+		"  o   o            \n"
+	if got := g.Render(life.WithAnnotations()); got != want {
+		t.Errorf("want: %#v, got: %#v", want, got)
+	}
+}
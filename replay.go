@@ -0,0 +1,196 @@
+package life
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"sync"
+	"time"
+)
+
+// ndjsonFrame is a line of a recording written by NewNDJSONRecorder
+type ndjsonFrame struct {
+	Generation int    `json:"generation"`
+	Board      string `json:"board"` // as produced by Encode
+}
+
+// NewNDJSONRecorder creates a UI which records each generation it is given to
+// w as a line of JSON, e.g. {"generation":3,"board":"..."}, where board is the
+// generation's Encode code. A Replay plays the recording back. Failures to
+// write are logged to the logger given to the game by WithLogger.
+func NewNDJSONRecorder(w io.Writer) UI {
+	return &ndjsonRecorder{enc: json.NewEncoder(w)}
+}
+
+type ndjsonRecorder struct {
+	enc    *json.Encoder
+	logger *log.Logger
+}
+
+// setLogger sets where failures to write a frame are logged
+func (r *ndjsonRecorder) setLogger(l *log.Logger) {
+	r.logger = l
+}
+
+// ClearScreen does nothing
+func (*ndjsonRecorder) ClearScreen() {}
+
+// Write discards the text frame
+func (*ndjsonRecorder) Write(string) {}
+
+// WriteGeneration records the nth generation
+func (r *ndjsonRecorder) WriteGeneration(n int, g *Generation) {
+	if err := r.enc.Encode(ndjsonFrame{Generation: n, Board: g.Encode()}); err != nil && r.logger != nil {
+		r.logger.Printf("life: recording generation %d: %v", n, err)
+	}
+}
+
+// NewNDJSONReplay creates a replay of a recording made by NewNDJSONRecorder,
+// drawing a frame to ui every rate. The replay starts when Run is called.
+func NewNDJSONReplay(r io.Reader, ui UI, rate time.Duration) *Replay {
+	return &Replay{
+		dec:   json.NewDecoder(r),
+		ui:    ui,
+		rate:  rate,
+		speed: 1,
+		wake:  make(chan struct{}, 1),
+		stop:  make(chan struct{}),
+	}
+}
+
+// Replay plays back a recorded run. Like a Game it can be paused, resumed and
+// stopped while it plays, and it can also be stepped a frame at a time and
+// sped up or slowed down without changing the recording.
+type Replay struct {
+	dec  *json.Decoder
+	ui   UI
+	rate time.Duration
+
+	mu     sync.Mutex
+	paused bool
+	steps  int // frames to show while paused
+	speed  float64
+
+	wake     chan struct{} // signalled whenever a control is used
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// Run plays the recording, returning once Stop is called or with an error if
+// the recording is malformed. On reaching the end of the recording the last
+// frame stays on screen and the replay pauses until it is stopped.
+func (r *Replay) Run() error {
+	for n := 0; ; n++ {
+		var f ndjsonFrame
+		err := r.dec.Decode(&f)
+		if errors.Is(err, io.EOF) {
+			r.Pause()
+			<-r.stop
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("life: replaying frame %d: %w", n, err)
+		}
+
+		g, err := Decode(f.Board)
+		if err != nil {
+			return fmt.Errorf("life: replaying generation %d: %w", f.Generation, err)
+		}
+		r.draw(f.Generation, g)
+
+		if !r.waitForNext() {
+			return nil
+		}
+	}
+}
+
+// draw shows the nth generation
+func (r *Replay) draw(n int, g *Generation) {
+	if ui, ok := r.ui.(GenerationUI); ok {
+		ui.WriteGeneration(n, g)
+		return
+	}
+
+	r.ui.ClearScreen()
+	r.ui.Write(g.String())
+}
+
+// waitForNext blocks until the next frame is due, returning false if the
+// replay was stopped in the meantime
+func (r *Replay) waitForNext() bool {
+	for {
+		r.mu.Lock()
+		paused, delay := r.paused, time.Duration(float64(r.rate)/r.speed)
+		if paused && r.steps > 0 {
+			r.steps--
+			r.mu.Unlock()
+			return true
+		}
+		r.mu.Unlock()
+
+		var due <-chan time.Time
+		if !paused {
+			due = time.After(delay)
+		}
+
+		select {
+		case <-due:
+			return true
+		case <-r.wake:
+		case <-r.stop:
+			return false
+		}
+	}
+}
+
+// Pause holds the current frame until Resume or Step is called
+func (r *Replay) Pause() {
+	r.control(func() { r.paused = true })
+}
+
+// Resume continues a paused replay
+func (r *Replay) Resume() {
+	r.control(func() { r.paused, r.steps = false, 0 })
+}
+
+// Step pauses the replay if it is playing, and otherwise shows the next frame
+func (r *Replay) Step() {
+	r.control(func() {
+		if r.paused {
+			r.steps++
+		}
+		r.paused = true
+	})
+}
+
+// SetSpeed plays the recording factor times faster than its rate, or slower
+// for a factor below one. Factors which are not positive are ignored.
+func (r *Replay) SetSpeed(factor float64) {
+	if factor <= 0 {
+		return
+	}
+	r.control(func() { r.speed = factor })
+}
+
+// Stop ends the replay, causing Run to return. It is safe to call more than
+// once.
+func (r *Replay) Stop() {
+	r.stopOnce.Do(func() {
+		close(r.stop)
+	})
+}
+
+// control applies a change to the replay's controls and wakes the replay so it
+// takes effect at once
+func (r *Replay) control(change func()) {
+	r.mu.Lock()
+	change()
+	r.mu.Unlock()
+
+	select {
+	case r.wake <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,154 @@
+package life_test
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/enocom/life"
+)
+
+// record plays a blinker for the given number of generations, returning the
+// NDJSON recording
+func record(t *testing.T, generations int) *bytes.Buffer {
+	t.Helper()
+
+	var buf bytes.Buffer
+	blinker := newBoard(life.Dimension{X: 3, Y: 3}, [2]int{0, 1}, [2]int{1, 1}, [2]int{2, 1})
+	life.NewGame(
+		life.WithUI(life.NewNDJSONRecorder(&buf)),
+		life.WithGenerationRate(0),
+		life.WithMaxGenerations(generations),
+		life.WithGeneration(blinker),
+	).Run()
+
+	return &buf
+}
+
+// syncUI counts the frames written to it from another goroutine
+type syncUI struct {
+	mu     sync.Mutex
+	frames []string
+}
+
+func (s *syncUI) ClearScreen() {}
+
+func (s *syncUI) Write(frame string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.frames = append(s.frames, frame)
+}
+
+func (s *syncUI) count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.frames)
+}
+
+// waitFor polls until cond holds, failing the test after a second
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestNDJSONRecorder(t *testing.T) {
+	lines := strings.Split(strings.TrimSpace(record(t, 2).String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("want: 3 frames, got: %v", lines)
+	}
+	if !strings.HasPrefix(lines[2], `{"generation":2,"board":"`) {
+		t.Errorf("want: a frame for generation 2, got: %v", lines[2])
+	}
+}
+
+// brokenWriter fails every write
+type brokenWriter struct{}
+
+func (brokenWriter) Write([]byte) (int, error) {
+	return 0, errors.New("broken")
+}
+
+func TestNDJSONRecorderLogsFailures(t *testing.T) {
+	var logs bytes.Buffer
+	life.NewGame(
+		life.WithUI(life.NewNDJSONRecorder(brokenWriter{})),
+		life.WithGenerationRate(0),
+		life.WithMaxGenerations(1),
+		life.WithLogger(log.New(&logs, "", 0)),
+	).Run()
+
+	if !strings.Contains(logs.String(), "recording generation 0: broken") {
+		t.Errorf("want: the failed frame in the game's log, got: %#v", logs.String())
+	}
+}
+
+func TestReplayPausesAtEnd(t *testing.T) {
+	var ui syncUI
+	r := life.NewNDJSONReplay(record(t, 3), &ui, time.Hour)
+	r.SetSpeed(1e9)
+
+	done := make(chan error)
+	go func() { done <- r.Run() }()
+
+	waitFor(t, func() bool { return ui.count() == 4 })
+	select {
+	case err := <-done:
+		t.Fatalf("want: the replay to pause on the last frame, got: Run returned %v", err)
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	r.Stop()
+	if err := <-done; err != nil {
+		t.Errorf("want: no error, got: %v", err)
+	}
+
+	horizontal, vertical := "     \no o o\n     \n", "  o  \n  o  \n  o  \n"
+	want := []string{horizontal, vertical, horizontal, vertical}
+	for i, frame := range ui.frames {
+		if frame != want[i] {
+			t.Errorf("frame %d: want: %#v, got: %#v", i, want[i], frame)
+		}
+	}
+}
+
+func TestReplayStep(t *testing.T) {
+	var ui syncUI
+	r := life.NewNDJSONReplay(record(t, 3), &ui, time.Hour)
+	r.Pause()
+
+	done := make(chan error)
+	go func() { done <- r.Run() }()
+	defer func() {
+		r.Stop()
+		<-done
+	}()
+
+	waitFor(t, func() bool { return ui.count() == 1 })
+	r.Step()
+	waitFor(t, func() bool { return ui.count() == 2 })
+	r.Step()
+	waitFor(t, func() bool { return ui.count() == 3 })
+
+	time.Sleep(10 * time.Millisecond)
+	if got := ui.count(); got != 3 {
+		t.Errorf("want: the replay to stay paused after stepping, got: %v frames", got)
+	}
+}
+
+func TestReplayMalformed(t *testing.T) {
+	r := life.NewNDJSONReplay(strings.NewReader("{\"board\": \"!!\"}\n"), &syncUI{}, 0)
+	if err := r.Run(); err == nil {
+		t.Error("want: an error for a malformed recording")
+	}
+}
@@ -0,0 +1,29 @@
+package life
+
+// Resize returns a copy of the generation on a board of size d. Cells keep
+// their coordinates, so growing the board adds dead cells along the right and
+// bottom edges while shrinking it drops the cells beyond them. Cell ages move
+// along with the cells. A negative dimension is treated as zero, giving a
+// board with no cells.
+func (g *Generation) Resize(d Dimension) *Generation {
+	d = Dimension{X: max(d.X, 0), Y: max(d.Y, 0)}
+	cells := make([]Cell, d.X*d.Y)
+	var ages []int
+	if g.ages != nil {
+		ages = make([]int, len(cells))
+	}
+	for y := 0; y < d.Y && y < g.dimensions.Y; y++ {
+		for x := 0; x < d.X && x < g.dimensions.X; x++ {
+			cells[x+y*d.X] = g.cells[x+y*g.dimensions.X]
+			if ages != nil {
+				ages[x+y*d.X] = g.ages[x+y*g.dimensions.X]
+			}
+		}
+	}
+
+	next := g.successor(cells)
+	next.dimensions = d
+	next.ages = ages
+
+	return next
+}
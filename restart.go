@@ -0,0 +1,46 @@
+package life
+
+import "time"
+
+// WithAutoRestart plays the game forever, screensaver style: when the board
+// goes extinct, becomes stable or settles into a cycle the game waits for
+// delay, then reseeds the board from the generation options, randomly by
+// default, and carries on. Generations are counted across restarts, with each
+// reseeded board counting as one, so WithMaxGenerations still bounds the whole
+// run. Restarts reports how many times the board was reseeded.
+func WithAutoRestart(delay time.Duration) GameOption {
+	return func(g *Game) {
+		g.autoRestart = true
+		g.restartDelay = delay
+	}
+}
+
+// Restarts returns how many times WithAutoRestart has reseeded the board. It
+// is safe to call while the game runs.
+func (g *Game) Restarts() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.restarts
+}
+
+// restart waits for the restart delay and returns a freshly seeded board,
+// moving the frame schedule back so the new board is shown for a whole frame.
+// It returns false if the game was stopped while waiting.
+func (g *Game) restart(frames *schedule) (*Generation, bool) {
+	began := time.Now()
+	select {
+	case <-time.After(g.restartDelay):
+	case <-g.stop:
+		return nil, false
+	}
+	frames.delay(time.Since(began) + g.rate)
+
+	gen := g.generate()
+
+	g.mu.Lock()
+	g.restarts++
+	g.mu.Unlock()
+
+	return gen, true
+}
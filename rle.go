@@ -0,0 +1,285 @@
+package life
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// MaxPatternDimension is the largest width or height accepted when loading a
+// pattern, protecting against headers which would exhaust memory
+const MaxPatternDimension = 4096
+
+// LoadRLE reads a pattern in the run length encoded (.rle) format. Comment
+// lines beginning with '#' may precede a header such as
+// "x = 3, y = 3, rule = B3/S23" giving the size of the board, which must not
+// exceed MaxPatternDimension on either side. The body encodes runs of dead
+// cells as 'b', live cells as 'o' and the ends of rows as '$', each optionally
+// preceded by a count, and ends with '!'. Patterns for Generations rules
+// instead write dead cells as '.' and each other state as a letter, 'A' for
+// live cells and 'B' onwards for dying ones, with states beyond 'X' taking a
+// prefix from 'p' to 'y'. The "#N", "#O" and "#C" comment
+// lines and the header's rule are kept as the generation's PatternMeta, and the
+// generation follows the rule.
+func LoadRLE(r io.Reader) (*Generation, error) {
+	s := bufio.NewScanner(r)
+
+	var (
+		d    Dimension
+		meta PatternMeta
+		err  error
+	)
+	for {
+		if !s.Scan() {
+			if err := s.Err(); err != nil {
+				return nil, err
+			}
+			return nil, errors.New("life: RLE pattern has no header")
+		}
+
+		line := strings.TrimSpace(s.Text())
+		if strings.HasPrefix(line, "#") {
+			meta.parseRLE(line)
+			continue
+		}
+		if line == "" {
+			continue
+		}
+		if d, meta.Rule, err = parseRLEHeader(line); err != nil {
+			return nil, err
+		}
+		break
+	}
+
+	cells := make([]Cell, d.X*d.Y)
+	x, y, count := 0, 0, ""
+	prefix := 0 // the multiple of 24 states named by a 'p' to 'y' prefix
+	for s.Scan() {
+		for _, ch := range s.Text() {
+			switch {
+			case ch >= '0' && ch <= '9':
+				count += string(ch)
+				continue
+			case ch == ' ' || ch == '\t' || ch == '\r':
+				continue
+			case ch == '!' && prefix == 0:
+				return newPattern(d, cells, meta)
+			case ch >= 'p' && ch <= 'y' && prefix == 0:
+				prefix = int(ch-'p') + 1
+				continue
+			}
+
+			n, err := runLength(count)
+			if err != nil {
+				return nil, err
+			}
+			count = ""
+
+			state := -1
+			switch {
+			case prefix > 0 && (ch < 'A' || ch > 'X'):
+				return nil, fmt.Errorf("life: unexpected %q after a state prefix in RLE pattern", ch)
+			case ch == '$':
+				x, y = 0, y+n
+			case ch == 'b' || ch == '.':
+				state = 0
+			case ch == 'o':
+				state = 1
+			case ch >= 'A' && ch <= 'X':
+				state = prefix*24 + int(ch-'A') + 1
+				prefix = 0
+			default:
+				return nil, fmt.Errorf("life: unexpected %q in RLE pattern", ch)
+			}
+			if state < 0 {
+				continue
+			}
+			if state >= maxStates {
+				return nil, fmt.Errorf("life: RLE state %d is beyond the largest of %d", state, maxStates-1)
+			}
+
+			if x+n > d.X || y >= d.Y {
+				return nil, fmt.Errorf("life: RLE row %d is larger than the %dx%d board", y, d.X, d.Y)
+			}
+			for i := 0; i < n; i++ {
+				cells[x+i+y*d.X] = cellInState(state)
+			}
+			x += n
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+
+	return nil, errors.New("life: RLE pattern is missing its terminating '!'")
+}
+
+// parseRLE records an RLE comment line
+func (m *PatternMeta) parseRLE(line string) {
+	if len(line) < 2 {
+		return
+	}
+
+	text := strings.TrimSpace(line[2:])
+	switch line[1] {
+	case 'N':
+		m.Name = text
+	case 'O':
+		m.Author = text
+	case 'C', 'c':
+		m.Comments = append(m.Comments, text)
+	}
+}
+
+// parseRLEHeader reads the board size and rule from an RLE header line
+func parseRLEHeader(line string) (d Dimension, rule string, err error) {
+	var sawX, sawY bool
+	for _, field := range strings.Split(line, ",") {
+		kv := strings.SplitN(field, "=", 2)
+		if len(kv) != 2 {
+			return d, rule, fmt.Errorf("life: malformed RLE header %q", line)
+		}
+
+		key, value := strings.TrimSpace(kv[0]), strings.TrimSpace(kv[1])
+		switch key {
+		case "x", "y":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 0 || n > MaxPatternDimension {
+				return d, rule, fmt.Errorf("life: RLE header %s = %q must be between 0 and %d",
+					key, value, MaxPatternDimension)
+			}
+			if key == "x" {
+				d.X, sawX = n, true
+			} else {
+				d.Y, sawY = n, true
+			}
+		case "rule":
+			rule = value
+		}
+	}
+
+	if !sawX || !sawY {
+		return d, rule, fmt.Errorf("life: RLE header %q must give both x and y", line)
+	}
+
+	return d, rule, nil
+}
+
+// runLength returns the count preceding an RLE tag, which defaults to one
+func runLength(count string) (int, error) {
+	if count == "" {
+		return 1, nil
+	}
+
+	n, err := strconv.Atoi(count)
+	if err != nil || n > MaxPatternDimension*MaxPatternDimension {
+		return 0, fmt.Errorf("life: RLE run length %q is too large", count)
+	}
+
+	return n, nil
+}
+
+// rleLineLength is the longest line WriteRLE writes
+const rleLineLength = 70
+
+// WriteRLE writes the generation in the run length encoded (.rle) format,
+// including its PatternMeta as comments and the header's rule. The header
+// gives the rule the generation follows in canonical form, rather than the
+// rule recorded in its PatternMeta.
+func (g *Generation) WriteRLE(w io.Writer) error {
+	b := bufio.NewWriter(w)
+
+	m := g.meta
+	if m.Name != "" {
+		fmt.Fprintf(b, "#N %s\n", m.Name)
+	}
+	if m.Author != "" {
+		fmt.Fprintf(b, "#O %s\n", m.Author)
+	}
+	for _, c := range m.Comments {
+		fmt.Fprintf(b, "#C %s\n", c)
+	}
+
+	fmt.Fprintf(b, "x = %d, y = %d, rule = %s\n", g.dimensions.X, g.dimensions.Y, g.rule)
+
+	multistate := g.rule.States() > 2
+	line := 0
+	emit := func(n int, tag string) {
+		token := tag
+		if n > 1 {
+			token = strconv.Itoa(n) + token
+		}
+		if line+len(token) > rleLineLength {
+			b.WriteByte('\n')
+			line = 0
+		}
+		b.WriteString(token)
+		line += len(token)
+	}
+
+	rowEnds := 0
+	for y := 0; y < g.dimensions.Y; y++ {
+		if y > 0 {
+			rowEnds++
+		}
+
+		row := g.cells[y*g.dimensions.X : (y+1)*g.dimensions.X]
+		for len(row) > 0 && row[len(row)-1].State() == 0 {
+			row = row[:len(row)-1]
+		}
+		if len(row) == 0 {
+			continue
+		}
+
+		if rowEnds > 0 {
+			emit(rowEnds, "$")
+			rowEnds = 0
+		}
+		for start := 0; start < len(row); {
+			end := start
+			for end < len(row) && row[end] == row[start] {
+				end++
+			}
+			emit(end-start, rleTag(row[start], multistate))
+			start = end
+		}
+	}
+	emit(1, "!")
+	b.WriteByte('\n')
+
+	return b.Flush()
+}
+
+// RLEString returns the generation in the run length encoded (.rle) format,
+// as written by WriteRLE, ready to paste wherever a pattern is shared
+func (g *Generation) RLEString() string {
+	var b strings.Builder
+	// writing to a strings.Builder cannot fail
+	_ = g.WriteRLE(&b)
+
+	return b.String()
+}
+
+// rleTag returns the tag which encodes the state of c, as a letter when the
+// pattern is for a Generations rule
+func rleTag(c Cell, multistate bool) string {
+	state := c.State()
+	switch {
+	case !multistate && c.Alive():
+		return "o"
+	case !multistate:
+		return "b"
+	case state == 0:
+		return "."
+	}
+
+	tag := string(rune('A' + (state-1)%24))
+	if state > 24 {
+		tag = string(rune('p'+(state-1)/24-1)) + tag
+	}
+
+	return tag
+}
@@ -0,0 +1,122 @@
+package life_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/enocom/life"
+)
+
+func TestLoadRLE(t *testing.T) {
+	pattern := "#N Glider\n#C A small spaceship\nx = 3, y = 3, rule = B3/S23\nbo$2bo$3o!\n"
+
+	g, err := life.LoadRLE(strings.NewReader(pattern))
+	if err != nil {
+		t.Fatalf("want: no error, got: %v", err)
+	}
+
+	want := newBoard(life.Dimension{X: 3, Y: 3},
+		[2]int{1, 0}, [2]int{2, 1}, [2]int{0, 2}, [2]int{1, 2}, [2]int{2, 2},
+	)
+	if g.Dimension() != want.Dimension() || !equal(g.Cells(), want.Cells()) {
+		t.Errorf("want: %v, got: %v", want, g)
+	}
+}
+
+func TestLoadRLEMultipleRowsAndLines(t *testing.T) {
+	// a run of row ends skips blank rows, and the body may span lines
+	pattern := "x = 4, y = 4\n2o2$\nb\nobo!"
+
+	g, err := life.LoadRLE(strings.NewReader(pattern))
+	if err != nil {
+		t.Fatalf("want: no error, got: %v", err)
+	}
+
+	want := newBoard(life.Dimension{X: 4, Y: 4}, [2]int{0, 0}, [2]int{1, 0}, [2]int{1, 2}, [2]int{3, 2})
+	if !equal(g.Cells(), want.Cells()) {
+		t.Errorf("want: %v, got: %v", want, g)
+	}
+}
+
+func TestLoadRLEErrors(t *testing.T) {
+	testCases := map[string]string{
+		"empty":            "",
+		"no size":          "rule = B3/S23\no!",
+		"malformed header": "x 3, y 3\no!",
+		"negative size":    "x = -1, y = 3\n!",
+		"huge header":      "x = 100000000, y = 100000000\n!",
+		"row too wide":     "x = 2, y = 1\n3o!",
+		"too many rows":    "x = 2, y = 1\no$o!",
+		"huge run":         "x = 2, y = 2\n99999999999999999999o!",
+		"unknown tag":      "x = 2, y = 2\nq!",
+		"missing end":      "x = 2, y = 2\noo$oo",
+	}
+
+	for description, pattern := range testCases {
+		if _, err := life.LoadRLE(strings.NewReader(pattern)); err == nil {
+			t.Errorf("(%s): want an error, got nil", description)
+		}
+	}
+}
+
+func FuzzLoadRLE(f *testing.F) {
+	f.Add([]byte("x = 3, y = 3, rule = B3/S23\nbo$2bo$3o!\n"))
+	f.Add([]byte("#C comment\nx = 0, y = 0\n!"))
+	f.Add([]byte("x = 2, y = 2\n5o!"))
+	f.Add([]byte("x = 1, y = 1\n$$$$$$$$o"))
+	f.Add([]byte("x = 4096, y = 4096\n4096b!"))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		g, err := life.LoadRLE(strings.NewReader(string(data)))
+		if err != nil {
+			return
+		}
+
+		d := g.Dimension()
+		if d.X > life.MaxPatternDimension || d.Y > life.MaxPatternDimension {
+			t.Fatalf("want: dimensions within %v, got: %v", life.MaxPatternDimension, d)
+		}
+		if len(g.Cells()) != d.X*d.Y {
+			t.Fatalf("want: %v cells, got: %v", d.X*d.Y, len(g.Cells()))
+		}
+	})
+}
+
+func TestRLEString(t *testing.T) {
+	glider := newBoard(life.Dimension{X: 3, Y: 3},
+		[2]int{1, 0}, [2]int{2, 1}, [2]int{0, 2}, [2]int{1, 2}, [2]int{2, 2},
+	)
+
+	got := glider.RLEString()
+	want := "x = 3, y = 3, rule = B3/S23\nbo$2bo$3o!\n"
+	if got != want {
+		t.Errorf("want: %#v, got: %#v", want, got)
+	}
+
+	g, err := life.LoadRLE(strings.NewReader(got))
+	if err != nil {
+		t.Fatalf("want: no error, got: %v", err)
+	}
+	if g.Dimension() != glider.Dimension() || !equal(g.Cells(), glider.Cells()) {
+		t.Errorf("want: %v, got: %v", glider, g)
+	}
+}
+
+func TestRLEStringFollowsRule(t *testing.T) {
+	highLife, err := life.ParseRule("B36/S23")
+	if err != nil {
+		t.Fatalf("want: no error, got: %v", err)
+	}
+	g := life.NewGeneration(
+		life.WithDimension(life.Dimension{X: 1, Y: 1}),
+		life.WithCells([]life.Cell{life.NewLiveCell()}),
+		life.WithMeta(life.PatternMeta{Rule: "B3/S23"}),
+		life.WithRule(highLife),
+	)
+
+	got := g.RLEString()
+	want := "x = 1, y = 1, rule = B36/S23\no!\n"
+	if got != want {
+		t.Errorf("want: %#v, got: %#v", want, got)
+	}
+}
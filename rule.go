@@ -0,0 +1,191 @@
+package life
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Rule decides the fate of each cell from its number of live neighbors: a dead
+// cell is born when its count is one of the rule's birth counts, and a live
+// cell survives when its count is one of the survival counts. Under a
+// Generations rule with more than two states, a live cell which does not
+// survive begins dying instead of dying at once, passing through each of the
+// remaining states in turn before it is dead. Dying cells do not count as
+// live neighbors and nothing can be born in their place.
+type Rule struct {
+	birth    [9]bool
+	survival [9]bool
+	states   int // zero for an ordinary two state rule
+}
+
+// Conway is the rule of Conway's Game of Life, B3/S23, and the default for
+// every generation
+var Conway = Rule{
+	birth:    [9]bool{3: true},
+	survival: [9]bool{2: true, 3: true},
+}
+
+// ParseRule reads a rule written in B/S notation, such as "B3/S23" for
+// Conway's rule or "B36/S23" for HighLife, where the digits after B are the
+// birth counts and those after S the survival counts. The letters may be
+// lowercase and the parts may come in either order. The older S/B notation
+// without letters, such as "23/3", is also accepted. A third part gives the
+// number of states of a Generations rule, either prefixed by G or C, as in
+// "B2/S/C3", or after the S/B parts, as in "/2/3" for Brian's Brain.
+func ParseRule(s string) (Rule, error) {
+	var r Rule
+
+	parts := strings.Split(strings.TrimSpace(s), "/")
+	if len(parts) != 2 && len(parts) != 3 {
+		return r, ruleError(s)
+	}
+
+	// S/B notation: survival counts first, no letters
+	if !strings.ContainsAny(s, "BbSsCcGg") {
+		parts[0], parts[1] = "S"+parts[0], "B"+parts[1]
+		if len(parts) == 3 {
+			parts[2] = "C" + parts[2]
+		}
+	}
+
+	var sawB, sawS bool
+	for _, part := range parts {
+		if part == "" {
+			return r, ruleError(s)
+		}
+
+		var counts *[9]bool
+		switch part[0] {
+		case 'B', 'b':
+			if sawB {
+				return r, ruleError(s)
+			}
+			counts, sawB = &r.birth, true
+		case 'S', 's':
+			if sawS {
+				return r, ruleError(s)
+			}
+			counts, sawS = &r.survival, true
+		case 'C', 'c', 'G', 'g':
+			n, err := strconv.Atoi(part[1:])
+			if err != nil || r.states != 0 || n < 2 || n > maxStates {
+				return r, ruleError(s)
+			}
+			r.states = n
+			continue
+		default:
+			return r, ruleError(s)
+		}
+
+		for _, ch := range part[1:] {
+			if ch < '0' || ch > '8' {
+				return r, ruleError(s)
+			}
+			counts[ch-'0'] = true
+		}
+	}
+
+	if !sawB || !sawS {
+		return r, ruleError(s)
+	}
+	if r.states == 2 {
+		r.states = 0
+	}
+
+	return r, nil
+}
+
+func ruleError(s string) error {
+	return fmt.Errorf("life: invalid rule %q: want B/S notation such as B3/S23", s)
+}
+
+// maxStates is the largest number of states a Generations rule may have
+const maxStates = 256
+
+// WithGenerationsRule configures a Generations rule: the birth and survival
+// counts of r with the given number of states, including the live and dead
+// ones. A rule with two states is the same as WithRule.
+func WithGenerationsRule(r Rule, states int) Option {
+	return func(g *Generation) {
+		r.states = 0
+		if states > 2 {
+			r.states = min(states, maxStates)
+		}
+		g.rule = r
+	}
+}
+
+// States returns the number of states a cell may be in under the rule, which
+// is two unless it is a Generations rule
+func (r Rule) States() int {
+	if r.states == 0 {
+		return 2
+	}
+
+	return r.states
+}
+
+// String returns the rule in canonical B/S notation, with the birth and
+// survival counts each in ascending order, as in "B3/S23". A Generations rule
+// has its number of states appended, as in "B2/S/C3".
+func (r Rule) String() string {
+	var b strings.Builder
+	b.WriteByte('B')
+	writeCounts(&b, r.birth)
+	b.WriteString("/S")
+	writeCounts(&b, r.survival)
+	if r.states != 0 {
+		fmt.Fprintf(&b, "/C%d", r.states)
+	}
+
+	return b.String()
+}
+
+func writeCounts(b *strings.Builder, counts [9]bool) {
+	for n, ok := range counts {
+		if ok {
+			b.WriteByte(byte('0' + n))
+		}
+	}
+}
+
+// Equal reports whether r and other produce the same next generation from
+// every board, however each was written
+func (r Rule) Equal(other Rule) bool {
+	return r == other
+}
+
+// WithRule configures the rule used to produce the next generation. The
+// default is Conway.
+func WithRule(r Rule) Option {
+	return func(g *Generation) {
+		g.rule = r
+	}
+}
+
+// Rule returns the rule the generation follows
+func (g *Generation) Rule() Rule {
+	return g.rule
+}
+
+// next returns the state which follows c when it has liveNeighbors
+func (r Rule) next(c Cell, liveNeighbors int) Cell {
+	switch {
+	case c.dying > 0:
+		if int(c.dying)+2 >= r.States() {
+			return Cell{}
+		}
+		return Cell{dying: c.dying + 1}
+	case c.Alive():
+		if r.survival[liveNeighbors] {
+			return c
+		}
+		if r.States() > 2 {
+			return Cell{dying: 1}
+		}
+		return Cell{}
+	}
+
+	return Cell{alive: r.birth[liveNeighbors]}
+}
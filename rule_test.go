@@ -0,0 +1,239 @@
+package life_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/enocom/life"
+)
+
+func TestParseRule(t *testing.T) {
+	testCases := map[string]bool{
+		"B3/S23":  true,
+		"b3/s23":  true,
+		"S23/B3":  true,
+		"23/3":    true,
+		"B36/S23": true,
+		"B/S":     true,
+		// Generations rules
+		"B3/S23/G8":    true,
+		"23/3/8":       true,
+		"/2/3":         true,
+		"B2/S/C3":      true,
+		"B3/S23/C1":    false,
+		"B3/S23/C3/C4": false,
+		"B3/S23/G3000": false,
+		"B3/S23/B3":    false,
+		"B2/S/Cx":      false,
+		"B3":           false,
+		"B9/S23":       false,
+		"X3/S23":       false,
+		"B3/B23":       false,
+		"":             false,
+	}
+
+	for rule, valid := range testCases {
+		_, err := life.ParseRule(rule)
+		if valid && err != nil {
+			t.Errorf("(%s): want no error, got %v", rule, err)
+		}
+		if !valid && err == nil {
+			t.Errorf("(%s): want an error, got nil", rule)
+		}
+	}
+
+	for _, rule := range []string{"b3/s23", "S23/B3", "23/3"} {
+		if r, _ := life.ParseRule(rule); r != life.Conway {
+			t.Errorf("(%s): want Conway's rule", rule)
+		}
+	}
+}
+
+func TestRuleString(t *testing.T) {
+	testCases := map[string]string{
+		"B3/S23":    "B3/S23",
+		"B33/S32":   "B3/S23",
+		"S2332/B63": "B36/S23",
+		"23/3":      "B3/S23",
+		"b/s":       "B/S",
+		"/2/3":      "B2/S/C3",
+		"B3/S23/G2": "B3/S23",
+	}
+
+	for rule, want := range testCases {
+		r, err := life.ParseRule(rule)
+		if err != nil {
+			t.Fatalf("(%s): want no error, got %v", rule, err)
+		}
+		if got := r.String(); got != want {
+			t.Errorf("(%s): want: %v, got: %v", rule, want, got)
+		}
+		if again, _ := life.ParseRule(r.String()); !again.Equal(r) {
+			t.Errorf("(%s): want the canonical form to parse to the same rule", rule)
+		}
+	}
+}
+
+func TestRuleEqual(t *testing.T) {
+	a, _ := life.ParseRule("B63/S2323")
+	b, _ := life.ParseRule("23/36")
+	if !a.Equal(b) {
+		t.Errorf("want: %v to equal %v", a, b)
+	}
+	if a.Equal(life.Conway) {
+		t.Errorf("want: %v not to equal %v", a, life.Conway)
+	}
+}
+
+func TestWriteRLECanonicalRule(t *testing.T) {
+	highLife, _ := life.ParseRule("S32/B63")
+	g := life.NewGeneration(
+		life.WithDimension(life.Dimension{X: 1, Y: 1}),
+		life.WithCells([]life.Cell{life.NewLiveCell()}),
+		life.WithRule(highLife),
+	)
+	if got, want := g.RLEString(), "x = 1, y = 1, rule = B36/S23\no!\n"; got != want {
+		t.Errorf("want: %#v, got: %#v", want, got)
+	}
+
+	g, err := life.LoadRLE(strings.NewReader("x = 1, y = 1, rule = 32/3\no!"))
+	if err != nil {
+		t.Fatalf("want: no error, got: %v", err)
+	}
+	if got := g.RLEString(); !strings.HasPrefix(got, "x = 1, y = 1, rule = B3/S23\n") {
+		t.Errorf("want: the file's rule in canonical form, got: %#v", got)
+	}
+}
+
+func TestWithRule(t *testing.T) {
+	highLife, err := life.ParseRule("B36/S23")
+	if err != nil {
+		t.Fatalf("want: no error, got: %v", err)
+	}
+
+	// a dead cell with six live neighbors is only born under HighLife
+	//
+	// o o o
+	// - - -
+	// o o o
+	seed := []life.Cell{
+		life.NewLiveCell(), life.NewLiveCell(), life.NewLiveCell(),
+		life.NewDeadCell(), life.NewDeadCell(), life.NewDeadCell(),
+		life.NewLiveCell(), life.NewLiveCell(), life.NewLiveCell(),
+	}
+
+	testCases := map[string]struct {
+		rule life.Rule
+		want bool
+	}{
+		"Conway":   {rule: life.Conway, want: false},
+		"HighLife": {rule: highLife, want: true},
+	}
+
+	for description, tc := range testCases {
+		g := life.NewGeneration(
+			life.WithDimension(life.Dimension{X: 3, Y: 3}),
+			life.WithRule(tc.rule),
+			life.WithCells(seed),
+		)
+
+		if got := life.Next(g).Cells()[4].Alive(); got != tc.want {
+			t.Errorf("(%s): want %v, got %v", description, tc.want, got)
+		}
+	}
+}
+
+func TestLoadRLERule(t *testing.T) {
+	_, err := life.LoadRLE(strings.NewReader("x = 1, y = 1, rule = B3/S23/X\no!"))
+	if err == nil {
+		t.Error("want: an error for an invalid rule, got: nil")
+	}
+
+	g, err := life.LoadRLE(strings.NewReader("x = 3, y = 1, rule = B/S012345678\n3o!"))
+	if err != nil {
+		t.Fatalf("want: no error, got: %v", err)
+	}
+	if life.Next(g).Population() != 3 {
+		t.Errorf("want: every cell to survive under the file's rule, got: %v", life.Next(g))
+	}
+}
+
+func TestGenerationsRule(t *testing.T) {
+	// Brian's Brain: cells are born with two live neighbors, never survive,
+	// and spend one generation dying
+	brain, err := life.ParseRule("/2/3")
+	if err != nil {
+		t.Fatalf("want: no error, got: %v", err)
+	}
+	if brain.States() != 3 {
+		t.Errorf("want: 3 states, got: %v", brain.States())
+	}
+
+	g := life.NewGeneration(
+		life.WithDimension(life.Dimension{X: 4, Y: 3}),
+		life.WithCells(newBoard(life.Dimension{X: 4, Y: 3}, [2]int{1, 1}, [2]int{2, 1}).Cells()),
+		life.WithGenerationsRule(brain, 3),
+	)
+
+	states := func(g *life.Generation) []int {
+		var s []int
+		for _, c := range g.Cells() {
+			s = append(s, c.State())
+		}
+		return s
+	}
+
+	g = life.Next(g)
+	want := []int{
+		0, 1, 1, 0,
+		0, 2, 2, 0,
+		0, 1, 1, 0,
+	}
+	if got := states(g); !reflect.DeepEqual(got, want) {
+		t.Errorf("want: %v, got: %v", want, got)
+	}
+
+	// the dying cells die and the live ones begin dying
+	g = life.Next(g)
+	for i, c := range g.Cells() {
+		if c.State() == 1 && (i == 5 || i == 6) {
+			t.Errorf("want: no births where cells were dying, got: %v", states(g))
+		}
+	}
+	if got := g.Cells()[5].State(); got != 0 {
+		t.Errorf("want: the dying cell dead, got state %v", got)
+	}
+	if got := g.Cells()[1].State(); got != 2 {
+		t.Errorf("want: the live cell dying, got state %v", got)
+	}
+}
+
+func TestLoadRLEGenerations(t *testing.T) {
+	pattern := "x = 4, y = 3, rule = /2/3\n.2A$.2B$.2A!\n"
+	g, err := life.LoadRLE(strings.NewReader(pattern))
+	if err != nil {
+		t.Fatalf("want: no error, got: %v", err)
+	}
+	if g.Rule().States() != 3 {
+		t.Errorf("want: 3 states, got: %v", g.Rule().States())
+	}
+	if got := g.Cells()[5].State(); got != 2 {
+		t.Errorf("want: a dying cell, got state %v", got)
+	}
+
+	var buf strings.Builder
+	if err := g.WriteRLE(&buf); err != nil {
+		t.Fatalf("want: no error, got: %v", err)
+	}
+	if want := "x = 4, y = 3, rule = B2/S/C3\n.2A$.2B$.2A!\n"; buf.String() != want {
+		t.Errorf("want: %#v, got: %#v", want, buf.String())
+	}
+
+	if _, err := life.LoadRLE(strings.NewReader("x = 1, y = 1, rule = /2/3\nC!")); err == nil {
+		t.Error("want: an error for a state the rule does not have")
+	}
+	if _, err := life.LoadRLE(strings.NewReader("x = 1, y = 1, rule = B3/S23\nB!")); err == nil {
+		t.Error("want: an error for a dying cell under a two state rule")
+	}
+}
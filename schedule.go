@@ -0,0 +1,71 @@
+package life
+
+import "time"
+
+// schedule paces generations against an absolute timeline, where frame n is
+// due at start + n*rate. Measuring from the start rather than from the previous
+// frame keeps a slow generation from pushing every later frame back. A nil
+// schedule never waits.
+type schedule struct {
+	start time.Time
+	rate  time.Duration
+}
+
+// newSchedule returns a schedule beginning now, or nil when rate is not
+// positive and generations should run back to back
+func newSchedule(rate time.Duration) *schedule {
+	if rate <= 0 {
+		return nil
+	}
+
+	return &schedule{start: time.Now(), rate: rate}
+}
+
+// due returns when frame n should be produced
+func (s *schedule) due(n int) time.Time {
+	return s.start.Add(time.Duration(n) * s.rate)
+}
+
+// wait returns a channel which receives once frame n is due. A frame which is
+// already due is ready at once.
+func (s *schedule) wait(n int) <-chan time.Time {
+	if s != nil {
+		if d := time.Until(s.due(n)); d > 0 {
+			return time.After(d)
+		}
+	}
+
+	ready := make(chan time.Time)
+	close(ready)
+	return ready
+}
+
+// late reports whether frame n is already due, in which case the frame before
+// it is not worth drawing
+func (s *schedule) late(n int) bool {
+	return s != nil && !time.Now().Before(s.due(n))
+}
+
+// delay moves every remaining frame back by d, so time spent paused is not
+// made up by skipping frames
+func (s *schedule) delay(d time.Duration) {
+	if s != nil {
+		s.start = s.start.Add(d)
+	}
+}
+
+// retime changes the rate of the schedule, making frame n due now so the
+// frames after it keep an even pace. A nil schedule is left as it is.
+func (s *schedule) retime(n int, rate time.Duration) {
+	if s != nil {
+		s.rate = rate
+		s.moveTo(n)
+	}
+}
+
+// moveTo makes frame n due now, as when the game jumps to another generation
+func (s *schedule) moveTo(n int) {
+	if s != nil {
+		s.start = time.Now().Add(-time.Duration(n) * s.rate)
+	}
+}
@@ -0,0 +1,92 @@
+package life
+
+import (
+	"runtime"
+	"sync"
+)
+
+// SoupResult describes how a single random soup evolved
+type SoupResult struct {
+	Seed        int64
+	Generations int  // generations run before settling or reaching the limit
+	Settled     bool // whether the soup went extinct, became stable or cycled
+	Outcome     Event
+	Population  int // live cells in the final generation
+}
+
+// SoupOption configures a soup search
+type SoupOption func(*soupSearch)
+
+// WithConcurrency limits the number of soups simulated at once to n. It
+// defaults to the number of CPUs.
+func WithConcurrency(n int) SoupOption {
+	return func(s *soupSearch) {
+		s.concurrency = n
+	}
+}
+
+// WithSoupOptions configures each soup, e.g. with WithNeighborhood
+func WithSoupOptions(opts ...Option) SoupOption {
+	return func(s *soupSearch) {
+		s.opts = append(s.opts, opts...)
+	}
+}
+
+type soupSearch struct {
+	concurrency int
+	opts        []Option
+}
+
+// SoupSearch simulates n random soups of size d, seeded with seed, seed+1 and
+// so on, each until it settles or has run maxGen generations. The results are
+// ordered by seed regardless of the order in which the simulations finish. A
+// negative n is treated as zero, giving no results.
+func SoupSearch(seed int64, n int, d Dimension, maxGen int, opts ...SoupOption) []SoupResult {
+	s := soupSearch{concurrency: runtime.NumCPU()}
+	for _, o := range opts {
+		o(&s)
+	}
+	if s.concurrency < 1 {
+		s.concurrency = 1
+	}
+
+	results := make([]SoupResult, max(n, 0))
+	sem := make(chan struct{}, s.concurrency)
+
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			results[i] = s.run(seed+int64(i), d, maxGen)
+		}(i)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// run simulates a single soup
+func (s *soupSearch) run(seed int64, d Dimension, maxGen int) SoupResult {
+	opts := append([]Option{WithDimension(d), WithRandomSeed(seed)}, s.opts...)
+	gen := NewGeneration(opts...)
+
+	result := SoupResult{Seed: seed}
+	detect := newDetector()
+	for n := 0; ; n++ {
+		if e, ok := detect.observe(gen, n); ok {
+			result.Settled, result.Outcome = true, e
+		}
+		if result.Settled || n == maxGen {
+			result.Generations = n
+			break
+		}
+		gen = Next(gen)
+	}
+	result.Population = gen.Population()
+
+	return result
+}
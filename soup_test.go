@@ -0,0 +1,44 @@
+package life_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/enocom/life"
+)
+
+func TestSoupSearchDeterministic(t *testing.T) {
+	d := life.Dimension{X: 8, Y: 8}
+
+	serial := life.SoupSearch(100, 12, d, 200, life.WithConcurrency(1))
+	parallel := life.SoupSearch(100, 12, d, 200, life.WithConcurrency(4))
+
+	if !reflect.DeepEqual(serial, parallel) {
+		t.Errorf("want: results independent of concurrency, got %v and %v", serial, parallel)
+	}
+
+	for i, r := range parallel {
+		if r.Seed != 100+int64(i) {
+			t.Errorf("want: seed %v at index %v, got: %v", 100+i, i, r.Seed)
+		}
+	}
+}
+
+func TestSoupSearchSettles(t *testing.T) {
+	results := life.SoupSearch(1, 4, life.Dimension{X: 6, Y: 6}, 500)
+
+	for _, r := range results {
+		if !r.Settled {
+			t.Errorf("want: a small soup to settle within 500 generations, got: %+v", r)
+		}
+		if r.Outcome.Kind == life.Extinct && r.Population != 0 {
+			t.Errorf("want: no population for an extinct soup, got: %+v", r)
+		}
+	}
+}
+
+func TestSoupSearchNoSoups(t *testing.T) {
+	if results := life.SoupSearch(1, -3, life.Dimension{X: 6, Y: 6}, 10); len(results) != 0 {
+		t.Errorf("want: no results, got: %v", results)
+	}
+}
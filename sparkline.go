@@ -0,0 +1,68 @@
+package life
+
+import (
+	"fmt"
+	"strings"
+)
+
+// sparkBars are the bars of a sparkline, from lowest to highest
+var sparkBars = []rune("▁▂▃▄▅▆▇█")
+
+func newSparkline(n int) *sparkline {
+	return &sparkline{values: make([]int, 0, max(n, 0))}
+}
+
+// sparkline remembers the most recent populations of a running game, up to
+// the capacity of values
+type sparkline struct {
+	values []int
+	next   int // where the next value goes once values is full
+}
+
+// record adds a population, replacing the oldest once the window is full. A
+// nil sparkline records nothing.
+func (s *sparkline) record(population int) {
+	if s == nil || cap(s.values) == 0 {
+		return
+	}
+
+	if len(s.values) < cap(s.values) {
+		s.values = append(s.values, population)
+		return
+	}
+	s.values[s.next] = population
+	s.next = (s.next + 1) % len(s.values)
+}
+
+// header returns a line giving the latest population followed by a bar for
+// each remembered population, oldest first, scaled between the smallest and
+// largest of them
+func (s *sparkline) header() string {
+	if len(s.values) == 0 {
+		return ""
+	}
+
+	ordered := s.ordered()
+	lo, hi := ordered[0], ordered[0]
+	for _, v := range ordered {
+		lo, hi = min(lo, v), max(hi, v)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "population %d ", ordered[len(ordered)-1])
+	for _, v := range ordered {
+		bar := 0
+		if hi > lo {
+			bar = (v - lo) * (len(sparkBars) - 1) / (hi - lo)
+		}
+		b.WriteRune(sparkBars[bar])
+	}
+	b.WriteString("\n")
+
+	return b.String()
+}
+
+// ordered returns the remembered populations, oldest first
+func (s *sparkline) ordered() []int {
+	return append(append([]int(nil), s.values[s.next:]...), s.values[:s.next]...)
+}
@@ -0,0 +1,161 @@
+package life
+
+// Classification describes what a pattern settles into
+type Classification int
+
+const (
+	// Unsettled means the pattern did not settle in the time allowed
+	Unsettled Classification = iota
+	// Dead means no live cells remain
+	Dead
+	// StillLife means the board stopped changing
+	StillLife
+	// Oscillator means the board repeats a cycle of two or more states
+	Oscillator
+)
+
+// String returns a description of the classification
+func (c Classification) String() string {
+	switch c {
+	case Dead:
+		return "dead"
+	case StillLife:
+		return "still life"
+	case Oscillator:
+		return "oscillator"
+	default:
+		return "unsettled"
+	}
+}
+
+// TimeToStabilize evolves g under rule for up to maxGen generations, looking
+// for a board it has already seen, and returns the generation at which the
+// board first reached the state it then repeats along with what it settled
+// into. A board which starts out as a still life settles at generation zero,
+// and one which dies out settles at the first empty generation. The final
+// return value is false, with a classification of Unsettled, if the board did
+// not settle within maxGen generations. Unlike Period, the pattern evolves on
+// the board of g, so edges and boundary state take part.
+func TimeToStabilize(g *Generation, rule Rule, maxGen int) (int, Classification, bool) {
+	board := g.successor(g.cells)
+	board.rule = rule
+
+	seen := map[string]int{board.QuadKey(): 0}
+	for n := 1; n <= maxGen+1; n++ {
+		board = Next(board)
+
+		earlier, ok := seen[board.QuadKey()]
+		if !ok {
+			seen[board.QuadKey()] = n
+			continue
+		}
+		// the repeat itself may fall one generation past maxGen, as settling
+		// at maxGen can only be confirmed by the generation after it
+		if earlier > maxGen {
+			break
+		}
+
+		switch {
+		case board.IsEmpty():
+			return earlier, Dead, true
+		case n-earlier == 1:
+			return earlier, StillLife, true
+		default:
+			return earlier, Oscillator, true
+		}
+	}
+
+	return 0, Unsettled, false
+}
+
+// FirstGenerationAtPopulation evolves g under rule for up to maxGen
+// generations and returns the first generation whose population equals or
+// exceeds target, counting g itself as generation zero. The second return
+// value is false if no generation reached target within maxGen.
+func FirstGenerationAtPopulation(g *Generation, rule Rule, target, maxGen int) (int, bool) {
+	board := g.successor(g.cells)
+	board.rule = rule
+
+	for n := 0; n <= maxGen; n++ {
+		if board.Population() >= target {
+			return n, true
+		}
+		board = Next(board)
+	}
+
+	return 0, false
+}
+
+// RunToCompletion evolves g under rule without any UI until it settles or
+// maxGen generations have run, and returns the last generation produced, how
+// many generations were run and what the board settled into. The run stops as
+// soon as the outcome is known: at the first empty generation, at the first
+// generation identical to the one before it, or when a generation repeats an
+// earlier one. A board which has not settled after maxGen generations is
+// returned as it stands, classified as Unsettled. Like TimeToStabilize, the
+// pattern evolves on the board of g.
+func RunToCompletion(g *Generation, rule Rule, maxGen int) (final *Generation, gensRun int, cls Classification) {
+	board := g.successor(g.cells)
+	board.rule = rule
+	if board.IsEmpty() {
+		return board, 0, Dead
+	}
+
+	seen := map[string]int{board.QuadKey(): 0}
+	for n := 1; n <= maxGen; n++ {
+		board = Next(board)
+		if board.IsEmpty() {
+			return board, n, Dead
+		}
+
+		key := board.QuadKey()
+		if earlier, ok := seen[key]; ok {
+			if n-earlier == 1 {
+				return board, n, StillLife
+			}
+			return board, n, Oscillator
+		}
+		seen[key] = n
+	}
+
+	return board, maxGen, Unsettled
+}
+
+// Classify evolves the pattern of live cells on g under rule for up to maxGen
+// generations and reports what it settles into, as RunToCompletion does. Like
+// Period, and unlike RunToCompletion, the pattern evolves in otherwise empty
+// space, ignoring the board's edges, so nothing it grows into is cut off. A
+// spaceship never returns to a state it was in, so it is Unsettled.
+func Classify(g *Generation, rule Rule, maxGen int) Classification {
+	box, ok := g.BoundingBox()
+	if !ok {
+		return Dead
+	}
+	pattern := g.crop(box)
+	pattern.rule = rule
+
+	// a state is the pattern along with where it is
+	type state struct {
+		key    string
+		origin Rect
+	}
+	space := newOpenSpace(pattern, maxGen)
+	seen := map[state]int{{key: pattern.QuadKey()}: 0}
+	for n := 1; n <= maxGen; n++ {
+		phase, origin, ok := space.next()
+		if !ok {
+			return Dead
+		}
+
+		s := state{key: phase.QuadKey(), origin: origin}
+		if earlier, ok := seen[s]; ok {
+			if n-earlier == 1 {
+				return StillLife
+			}
+			return Oscillator
+		}
+		seen[s] = n
+	}
+
+	return Unsettled
+}
@@ -0,0 +1,211 @@
+package life_test
+
+import (
+	"testing"
+
+	"github.com/enocom/life"
+)
+
+func TestTimeToStabilize(t *testing.T) {
+	d := life.Dimension{X: 8, Y: 8}
+	testCases := map[string]struct {
+		g      *life.Generation
+		maxGen int
+		want   int
+		class  life.Classification
+		ok     bool
+	}{
+		"block": {
+			g:      newBoard(d, [2]int{1, 1}, [2]int{2, 1}, [2]int{1, 2}, [2]int{2, 2}),
+			maxGen: 10,
+			want:   0,
+			class:  life.StillLife,
+			ok:     true,
+		},
+		"blinker": {
+			g:      newBoard(d, [2]int{1, 2}, [2]int{2, 2}, [2]int{3, 2}),
+			maxGen: 10,
+			want:   0,
+			class:  life.Oscillator,
+			ok:     true,
+		},
+		"dies out": {
+			g:      newBoard(d, [2]int{1, 1}, [2]int{2, 1}),
+			maxGen: 10,
+			want:   1,
+			class:  life.Dead,
+			ok:     true,
+		},
+		"settles into a block": {
+			g:      newBoard(d, [2]int{1, 1}, [2]int{2, 1}, [2]int{1, 2}),
+			maxGen: 10,
+			want:   1,
+			class:  life.StillLife,
+			ok:     true,
+		},
+		"settles on the last generation allowed": {
+			g:      newBoard(d, [2]int{1, 1}, [2]int{2, 1}, [2]int{1, 2}),
+			maxGen: 1,
+			want:   1,
+			class:  life.StillLife,
+			ok:     true,
+		},
+		"glider before it reaches the edge": {
+			g:      newBoard(d, [2]int{1, 0}, [2]int{2, 1}, [2]int{0, 2}, [2]int{1, 2}, [2]int{2, 2}),
+			maxGen: 4,
+			class:  life.Unsettled,
+		},
+	}
+
+	for description, tc := range testCases {
+		got, class, ok := life.TimeToStabilize(tc.g, life.Conway, tc.maxGen)
+		if got != tc.want || class != tc.class || ok != tc.ok {
+			t.Errorf("(%s): want: %v %v (ok = %v), got: %v %v (ok = %v)",
+				description, tc.want, tc.class, tc.ok, got, class, ok)
+		}
+	}
+}
+
+func TestFirstGenerationAtPopulation(t *testing.T) {
+	// three cells which grow into a block
+	g := newBoard(life.Dimension{X: 4, Y: 4}, [2]int{1, 1}, [2]int{2, 1}, [2]int{1, 2})
+
+	testCases := map[string]struct {
+		target, maxGen int
+		want           int
+		ok             bool
+	}{
+		"reached at the start": {target: 3, maxGen: 5, want: 0, ok: true},
+		"reached later":        {target: 4, maxGen: 5, want: 1, ok: true},
+		"reached too late":     {target: 4, maxGen: 0},
+		"never reached":        {target: 5, maxGen: 5},
+	}
+
+	for description, tc := range testCases {
+		got, ok := life.FirstGenerationAtPopulation(g, life.Conway, tc.target, tc.maxGen)
+		if got != tc.want || ok != tc.ok {
+			t.Errorf("(%s): want: %v (ok = %v), got: %v (ok = %v)", description, tc.want, tc.ok, got, ok)
+		}
+	}
+}
+
+func TestRunToCompletion(t *testing.T) {
+	d := life.Dimension{X: 6, Y: 6}
+	testCases := map[string]struct {
+		g      *life.Generation
+		maxGen int
+		gens   int
+		class  life.Classification
+		final  *life.Generation
+	}{
+		"empty": {
+			g:     newBoard(d),
+			class: life.Dead,
+			final: newBoard(d),
+		},
+		"dies out": {
+			g:      newBoard(d, [2]int{1, 1}, [2]int{3, 3}),
+			maxGen: 10,
+			gens:   1,
+			class:  life.Dead,
+			final:  newBoard(d),
+		},
+		"settles into a block": {
+			g:      newBoard(d, [2]int{1, 1}, [2]int{2, 1}, [2]int{1, 2}),
+			maxGen: 10,
+			gens:   2,
+			class:  life.StillLife,
+			final:  newBoard(d, [2]int{1, 1}, [2]int{2, 1}, [2]int{1, 2}, [2]int{2, 2}),
+		},
+		"blinker": {
+			g:      newBoard(d, [2]int{1, 2}, [2]int{2, 2}, [2]int{3, 2}),
+			maxGen: 10,
+			gens:   2,
+			class:  life.Oscillator,
+			final:  newBoard(d, [2]int{1, 2}, [2]int{2, 2}, [2]int{3, 2}),
+		},
+		"out of time": {
+			g:      newBoard(d, [2]int{1, 2}, [2]int{2, 2}, [2]int{3, 2}),
+			maxGen: 1,
+			gens:   1,
+			class:  life.Unsettled,
+			final:  newBoard(d, [2]int{2, 1}, [2]int{2, 2}, [2]int{2, 3}),
+		},
+	}
+
+	for description, tc := range testCases {
+		final, gens, class := life.RunToCompletion(tc.g, life.Conway, tc.maxGen)
+		if gens != tc.gens || class != tc.class {
+			t.Errorf("(%s): want: %v generations, %v, got: %v generations, %v", description, tc.gens, tc.class, gens, class)
+		}
+		if !equal(final.Cells(), tc.final.Cells()) {
+			t.Errorf("(%s): want: %#v, got: %#v", description, tc.final.String(), final.String())
+		}
+	}
+}
+
+func TestStabilizeGenerationsRule(t *testing.T) {
+	rule := mustParseRule(t, "B3/S23/C3")
+	// the L becomes a block at once, while the lone cell takes a generation
+	// to die and another to fade, so the board only settles at generation 2
+	d := life.Dimension{X: 8, Y: 8}
+	g := newBoard(d, [2]int{1, 1}, [2]int{2, 1}, [2]int{1, 2}, [2]int{5, 5})
+
+	n, class, ok := life.TimeToStabilize(g, rule, 10)
+	if n != 2 || class != life.StillLife || !ok {
+		t.Errorf("want: 2 still life true, got: %v %v %v", n, class, ok)
+	}
+
+	final, gensRun, class := life.RunToCompletion(g, rule, 10)
+	if gensRun != 3 || class != life.StillLife {
+		t.Errorf("want: a still life after 3 generations, got: %v after %v", class, gensRun)
+	}
+	for i, c := range final.Cells() {
+		if c.State() > 1 {
+			t.Errorf("want: no dying cells left, got state %d at %d", c.State(), i)
+		}
+	}
+}
+
+func TestClassify(t *testing.T) {
+	testCases := map[string]struct {
+		g      *life.Generation
+		maxGen int
+		want   life.Classification
+	}{
+		"empty": {
+			g:    newBoard(life.Dimension{X: 3, Y: 3}),
+			want: life.Dead,
+		},
+		"lone cell": {
+			g:      newBoard(life.Dimension{X: 1, Y: 1}, [2]int{0, 0}),
+			maxGen: 10,
+			want:   life.Dead,
+		},
+		"block": {
+			g:      newBoard(life.Dimension{X: 2, Y: 2}, [2]int{0, 0}, [2]int{1, 0}, [2]int{0, 1}, [2]int{1, 1}),
+			maxGen: 10,
+			want:   life.StillLife,
+		},
+		// a blinker fills the board it is cropped to, so on that board it
+		// would die out
+		"blinker": {
+			g:      newBoard(life.Dimension{X: 3, Y: 1}, [2]int{0, 0}, [2]int{1, 0}, [2]int{2, 0}),
+			maxGen: 10,
+			want:   life.Oscillator,
+		},
+		"glider": {
+			g: newBoard(life.Dimension{X: 3, Y: 3},
+				[2]int{1, 0}, [2]int{2, 1}, [2]int{0, 2}, [2]int{1, 2}, [2]int{2, 2},
+			),
+			maxGen: 100,
+			want:   life.Unsettled,
+		},
+	}
+
+	for description, tc := range testCases {
+		if got := life.Classify(tc.g, life.Conway, tc.maxGen); got != tc.want {
+			t.Errorf("(%s): want: %v, got: %v", description, tc.want, got)
+		}
+	}
+}
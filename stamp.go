@@ -0,0 +1,62 @@
+package life
+
+import "fmt"
+
+// Stamp copies every cell of p onto the board with p's top left corner at
+// (offsetX, offsetY), replacing the cells beneath it, dead or alive. The
+// whole of p must fit on the board; otherwise Stamp returns an error and the
+// board is left unchanged.
+func (g *Generation) Stamp(p *Generation, offsetX, offsetY int) error {
+	region := Rect{X: offsetX, Y: offsetY, W: p.dimensions.X, H: p.dimensions.Y}
+	if region.intersect(Rect{W: g.dimensions.X, H: g.dimensions.Y}) != region {
+		return fmt.Errorf("life: a %dx%d pattern at (%d, %d) does not fit the %dx%d board",
+			p.dimensions.X, p.dimensions.Y, offsetX, offsetY, g.dimensions.X, g.dimensions.Y)
+	}
+
+	for i, c := range p.cells {
+		x, y := offsetX+i%p.dimensions.X, offsetY+i/p.dimensions.X
+		g.setCell(x+y*g.dimensions.X, c)
+	}
+
+	return nil
+}
+
+// AddPattern adds the live cells of p to the board with p's top left corner at
+// (offsetX, offsetY). Unlike Stamp, the dead cells of p are transparent, so
+// live cells already on the board stay alive and patterns can be layered one
+// over another. Every live cell of p must land on the board, though its dead
+// cells may hang over the edge; otherwise AddPattern returns an error and the
+// board is left unchanged. AddPatternClipped drops the cells beyond the edge
+// instead.
+func (g *Generation) AddPattern(p *Generation, offsetX, offsetY int) error {
+	return g.addPattern(p, offsetX, offsetY, true)
+}
+
+// AddPatternClipped is like AddPattern, but live cells of p which would land
+// beyond the edge of the board are dropped
+func (g *Generation) AddPatternClipped(p *Generation, offsetX, offsetY int) {
+	g.addPattern(p, offsetX, offsetY, false)
+}
+
+func (g *Generation) addPattern(p *Generation, offsetX, offsetY int, strict bool) error {
+	var live []int
+	for i, c := range p.cells {
+		if !c.Alive() {
+			continue
+		}
+		x, y := offsetX+i%p.dimensions.X, offsetY+i/p.dimensions.X
+		if err := g.checkOnBoard(x, y); err != nil {
+			if strict {
+				return err
+			}
+			continue
+		}
+		live = append(live, x+y*g.dimensions.X)
+	}
+
+	for _, idx := range live {
+		g.setCell(idx, NewLiveCell())
+	}
+
+	return nil
+}
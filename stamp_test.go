@@ -0,0 +1,69 @@
+package life_test
+
+import (
+	"testing"
+
+	"github.com/enocom/life"
+)
+
+func TestAddPattern(t *testing.T) {
+	d := life.Dimension{X: 5, Y: 4}
+	block := newBoard(life.Dimension{X: 2, Y: 2}, [2]int{0, 0}, [2]int{1, 0}, [2]int{0, 1}, [2]int{1, 1})
+	// a blinker with a dead cell either side, whose margin overlaps the block
+	blinker := newBoard(life.Dimension{X: 3, Y: 3}, [2]int{1, 0}, [2]int{1, 1}, [2]int{1, 2})
+
+	g := newBoard(d)
+	if err := g.AddPattern(block, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.AddPattern(blinker, 1, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	// o o . . .
+	// o o o . .
+	// . . o . .
+	// . . o . .
+	want := newBoard(d, [2]int{0, 0}, [2]int{1, 0}, [2]int{0, 1}, [2]int{1, 1}, [2]int{2, 1}, [2]int{2, 2}, [2]int{2, 3})
+	if !equal(g.Cells(), want.Cells()) {
+		t.Errorf("want: %v, got: %v", want, g)
+	}
+
+	// stamping the blinker instead clears the block cell under its margin
+	stamped := newBoard(d)
+	stamped.AddPattern(block, 0, 0)
+	if err := stamped.Stamp(blinker, 1, 1); err != nil {
+		t.Fatal(err)
+	}
+	want = newBoard(d, [2]int{0, 0}, [2]int{1, 0}, [2]int{0, 1}, [2]int{2, 1}, [2]int{2, 2}, [2]int{2, 3})
+	if !equal(stamped.Cells(), want.Cells()) {
+		t.Errorf("want: %v, got: %v", want, stamped)
+	}
+}
+
+func TestAddPatternEdges(t *testing.T) {
+	d := life.Dimension{X: 4, Y: 4}
+	blinker := newBoard(life.Dimension{X: 3, Y: 3}, [2]int{1, 0}, [2]int{1, 1}, [2]int{1, 2})
+
+	// only the dead margin hangs over the left edge
+	g := newBoard(d)
+	if err := g.AddPattern(blinker, -1, 0); err != nil {
+		t.Errorf("want: no error, got: %v", err)
+	}
+	if err := g.Stamp(blinker, -1, 0); err == nil {
+		t.Error("want: an error stamping past the edge")
+	}
+
+	g = newBoard(d)
+	if err := g.AddPattern(blinker, 0, 2); err == nil {
+		t.Error("want: an error for live cells past the edge")
+	}
+	if !g.IsEmpty() {
+		t.Errorf("want: the board left unchanged, got: %v", g)
+	}
+
+	g.AddPatternClipped(blinker, 0, 2)
+	if want := newBoard(d, [2]int{1, 2}, [2]int{1, 3}); !equal(g.Cells(), want.Cells()) {
+		t.Errorf("want: %v, got: %v", want, g)
+	}
+}
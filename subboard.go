@@ -0,0 +1,60 @@
+package life
+
+import "fmt"
+
+// SubBoard returns a copy of the size×size square of cells whose top left
+// corner is at (x, y). The square must lie entirely within the board. The
+// result keeps the generation's configuration, so square sub-boards, such as
+// the quadrants of a power-of-two board, can be compared or keyed by content.
+// A mask moves along with the cells.
+func (g *Generation) SubBoard(x, y, size int) (*Generation, error) {
+	region := Rect{X: x, Y: y, W: size, H: size}
+	if size < 0 || region.intersect(Rect{W: g.dimensions.X, H: g.dimensions.Y}) != region {
+		return nil, fmt.Errorf("life: sub-board %+v is outside the %dx%d board",
+			region, g.dimensions.X, g.dimensions.Y)
+	}
+
+	cells := make([]Cell, 0, size*size)
+	for row := y; row < y+size; row++ {
+		start := x + row*g.dimensions.X
+		cells = append(cells, g.cells[start:start+size]...)
+	}
+
+	sub := g.successor(cells)
+	sub.dimensions = Dimension{X: size, Y: size}
+	if g.mask != nil {
+		mask := g.mask
+		sub.mask = func(sx, sy int) bool { return mask(sx+x, sy+y) }
+	}
+
+	return sub, nil
+}
+
+// QuadKey returns a canonical key for the content of the board: two boards
+// share a key exactly when they have the same dimensions and live cells.
+// Under a rule with more than two states the dying cells must match as well,
+// since they decide what the board does next.
+func (g *Generation) QuadKey() string {
+	key := make([]byte, 0, len(g.cells)+len(g.cells)/8+16)
+	key = append(key, fmt.Sprintf("%dx%d:", g.dimensions.X, g.dimensions.Y)...)
+
+	if g.rule.States() > 2 {
+		for _, c := range g.cells {
+			key = append(key, byte(c.State()))
+		}
+		return string(key)
+	}
+
+	var b byte
+	for i, c := range g.cells {
+		if c.Alive() {
+			b |= 1 << uint(i%8)
+		}
+		if i%8 == 7 || i == len(g.cells)-1 {
+			key = append(key, b)
+			b = 0
+		}
+	}
+
+	return string(key)
+}
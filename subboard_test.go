@@ -0,0 +1,69 @@
+package life_test
+
+import (
+	"testing"
+
+	"github.com/enocom/life"
+)
+
+func TestSubBoard(t *testing.T) {
+	// o - - -
+	// - - o -
+	// - - - o
+	// - - o o
+	g := newBoard(life.Dimension{X: 4, Y: 4},
+		[2]int{0, 0}, [2]int{2, 1}, [2]int{3, 2}, [2]int{2, 3}, [2]int{3, 3},
+	)
+
+	sub, err := g.SubBoard(2, 2, 2)
+	if err != nil {
+		t.Fatalf("want: no error, got: %v", err)
+	}
+
+	want := newBoard(life.Dimension{X: 2, Y: 2}, [2]int{1, 0}, [2]int{0, 1}, [2]int{1, 1})
+	if sub.Dimension() != want.Dimension() || !equal(sub.Cells(), want.Cells()) {
+		t.Errorf("want: %v, got: %v", want, sub)
+	}
+}
+
+func TestSubBoardOutOfBounds(t *testing.T) {
+	g := newBoard(life.Dimension{X: 4, Y: 4})
+
+	testCases := map[string][3]int{
+		"negative origin": {-1, 0, 2},
+		"past the right":  {3, 0, 2},
+		"past the bottom": {0, 3, 2},
+		"negative size":   {0, 0, -1},
+	}
+
+	for description, tc := range testCases {
+		if _, err := g.SubBoard(tc[0], tc[1], tc[2]); err == nil {
+			t.Errorf("(%s): want an error, got nil", description)
+		}
+	}
+}
+
+func TestQuadKey(t *testing.T) {
+	// - o o -
+	// - o o -
+	g := newBoard(life.Dimension{X: 4, Y: 2},
+		[2]int{1, 0}, [2]int{2, 0}, [2]int{1, 1}, [2]int{2, 1},
+	)
+
+	left, _ := g.SubBoard(0, 0, 2)
+	right, _ := g.SubBoard(2, 0, 2)
+	if left.QuadKey() == right.QuadKey() {
+		t.Error("want: different content to have different keys")
+	}
+
+	mirror := newBoard(life.Dimension{X: 2, Y: 2}, [2]int{1, 0}, [2]int{1, 1})
+	if left.QuadKey() != mirror.QuadKey() {
+		t.Error("want: equal content to share a key")
+	}
+
+	wide := newBoard(life.Dimension{X: 4, Y: 1})
+	tall := newBoard(life.Dimension{X: 1, Y: 4})
+	if wide.QuadKey() == tall.QuadKey() {
+		t.Error("want: different dimensions to have different keys")
+	}
+}
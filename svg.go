@@ -0,0 +1,56 @@
+package life
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+// WithGrid draws a line between neighboring cells. Only WriteSVG draws the
+// grid; it is ignored by the PNG writers.
+func WithGrid() ImageOption {
+	return func(c *imageConfig) {
+		c.grid = true
+	}
+}
+
+// WriteSVG writes the board to w as an SVG image in which each cell is cellPx
+// by cellPx pixels, live cells in white on a black background, like WritePNG.
+// The viewBox spans the board's dimensions, one unit per cell, so the image
+// scales cleanly to any size. The live cells are drawn as a single path.
+// Options such as WithCellHeight and WithGrid change how cells are drawn.
+func (g *Generation) WriteSVG(w io.Writer, cellPx int, opts ...ImageOption) error {
+	c, err := newImageConfig(cellPx, opts)
+	if err != nil {
+		return err
+	}
+
+	d := g.dimensions
+	b := bufio.NewWriter(w)
+	fmt.Fprintf(b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" preserveAspectRatio="none">`+"\n",
+		d.X*c.cellW, d.Y*c.cellH, d.X, d.Y)
+	fmt.Fprintf(b, `<rect width="%d" height="%d" fill="black"/>`+"\n", d.X, d.Y)
+
+	b.WriteString(`<path fill="white" d="`)
+	for i, cell := range g.cells {
+		if cell.Alive() {
+			fmt.Fprintf(b, "M%d %dh1v1h-1z", i%d.X, i/d.X)
+		}
+	}
+	b.WriteString("\"/>\n")
+
+	if c.grid {
+		b.WriteString(`<path stroke="gray" stroke-width="1" vector-effect="non-scaling-stroke" d="`)
+		for x := 1; x < d.X; x++ {
+			fmt.Fprintf(b, "M%d 0V%d", x, d.Y)
+		}
+		for y := 1; y < d.Y; y++ {
+			fmt.Fprintf(b, "M0 %dH%d", y, d.X)
+		}
+		b.WriteString("\"/>\n")
+	}
+
+	b.WriteString("</svg>\n")
+
+	return b.Flush()
+}
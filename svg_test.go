@@ -0,0 +1,58 @@
+package life_test
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/enocom/life"
+)
+
+func TestWriteSVG(t *testing.T) {
+	// . o .
+	// . . o
+	g := newBoard(life.Dimension{X: 3, Y: 2}, [2]int{1, 0}, [2]int{2, 1})
+
+	var buf strings.Builder
+	if err := g.WriteSVG(&buf, 10); err != nil {
+		t.Fatalf("want: no error, got: %v", err)
+	}
+
+	want := `<svg xmlns="http://www.w3.org/2000/svg" width="30" height="20" viewBox="0 0 3 2" preserveAspectRatio="none">` + "\n" +
+		`<rect width="3" height="2" fill="black"/>` + "\n" +
+		`<path fill="white" d="M1 0h1v1h-1zM2 1h1v1h-1z"/>` + "\n" +
+		"</svg>\n"
+	if got := buf.String(); got != want {
+		t.Errorf("want: %#v, got: %#v", want, got)
+	}
+}
+
+func TestWriteSVGGrid(t *testing.T) {
+	g := newBoard(life.Dimension{X: 3, Y: 2}, [2]int{1, 0})
+
+	var buf strings.Builder
+	if err := g.WriteSVG(&buf, 4, life.WithGrid(), life.WithCellHeight(8)); err != nil {
+		t.Fatalf("want: no error, got: %v", err)
+	}
+
+	var svg struct {
+		Width  int `xml:"width,attr"`
+		Height int `xml:"height,attr"`
+		Paths  []struct {
+			D string `xml:"d,attr"`
+		} `xml:"path"`
+	}
+	if err := xml.Unmarshal([]byte(buf.String()), &svg); err != nil {
+		t.Fatalf("want: well formed XML, got: %v", err)
+	}
+	if svg.Width != 12 || svg.Height != 16 {
+		t.Errorf("want: a 12x16 image, got: %vx%v", svg.Width, svg.Height)
+	}
+	if len(svg.Paths) != 2 || svg.Paths[1].D != "M1 0V2M2 0V2M0 1H3" {
+		t.Errorf("want: cells and a grid, got: %+v", svg.Paths)
+	}
+
+	if err := g.WriteSVG(&buf, 0); err == nil {
+		t.Error("want: an error for a zero cell size")
+	}
+}
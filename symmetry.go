@@ -0,0 +1,241 @@
+package life
+
+import "math/rand"
+
+// Symmetry is a rotation or reflection which leaves a pattern unchanged
+type Symmetry int
+
+const (
+	// MirrorSymmetry is a reflection left to right, across a vertical axis
+	MirrorSymmetry Symmetry = iota
+	// FlipSymmetry is a reflection top to bottom, across a horizontal axis
+	FlipSymmetry
+	// DiagonalSymmetry is a reflection across the diagonal running from the
+	// top left to the bottom right
+	DiagonalSymmetry
+	// AntiDiagonalSymmetry is a reflection across the diagonal running from
+	// the top right to the bottom left
+	AntiDiagonalSymmetry
+	// HalfTurnSymmetry is a rotation by 180 degrees
+	HalfTurnSymmetry
+	// QuarterTurnSymmetry is a rotation by 90 degrees, which implies
+	// HalfTurnSymmetry
+	QuarterTurnSymmetry
+	// MirrorFlipSymmetry is reflection across both axes at once, so one
+	// quadrant of the board is mirrored into the other three. It implies
+	// MirrorSymmetry, FlipSymmetry and HalfTurnSymmetry, which Symmetries
+	// reports in its place.
+	MirrorFlipSymmetry
+)
+
+func (s Symmetry) String() string {
+	switch s {
+	case MirrorSymmetry:
+		return "mirror"
+	case FlipSymmetry:
+		return "flip"
+	case DiagonalSymmetry:
+		return "diagonal"
+	case AntiDiagonalSymmetry:
+		return "anti-diagonal"
+	case HalfTurnSymmetry:
+		return "half turn"
+	case QuarterTurnSymmetry:
+		return "quarter turn"
+	case MirrorFlipSymmetry:
+		return "mirror and flip"
+	default:
+		return "unknown"
+	}
+}
+
+// Symmetries returns the rotations and reflections under which the pattern
+// of live cells, cropped to its bounding box, is unchanged, in the order the
+// Symmetry constants are declared. A glider has none, a blinker is symmetric
+// under reflection in either axis and a half turn, and a block or a pulsar
+// under every one. An empty board is symmetric under every one.
+func (g *Generation) Symmetries() []Symmetry {
+	pattern := g.Normalize()
+
+	var symmetries []Symmetry
+	for s := MirrorSymmetry; s <= QuarterTurnSymmetry; s++ {
+		if pattern.sameCells(pattern.transformed(s)) {
+			symmetries = append(symmetries, s)
+		}
+	}
+
+	return symmetries
+}
+
+// transformed returns the image of the whole board under the symmetry
+func (g *Generation) transformed(s Symmetry) *Generation {
+	switch s {
+	case MirrorSymmetry:
+		return g.FlipHorizontal()
+	case FlipSymmetry:
+		return g.FlipVertical()
+	case DiagonalSymmetry:
+		return g.Rotate().FlipHorizontal()
+	case AntiDiagonalSymmetry:
+		return g.Rotate().FlipVertical()
+	case HalfTurnSymmetry:
+		return g.Rotate().Rotate()
+	case QuarterTurnSymmetry:
+		return g.Rotate()
+	default:
+		return g
+	}
+}
+
+// symmetryScore returns the fraction of cells which match their image under
+// s, or 0 for a symmetry which does not fit the board
+func (g *Generation) symmetryScore(s Symmetry) float64 {
+	if s == MirrorFlipSymmetry {
+		return min(g.symmetryScore(MirrorSymmetry), g.symmetryScore(FlipSymmetry))
+	}
+
+	score, err := Similarity(g, g.transformed(s))
+	if err != nil {
+		return 0
+	}
+
+	return score
+}
+
+// SymmetryOverTime evolves g under rule for steps generations on its own
+// board and returns, for the seed and each generation after it, the fraction
+// of cells which match their mirror image across the board's vertical axis,
+// as given by Similarity. A seed with mirror symmetry under a symmetric rule
+// such as Conway's scores 1 throughout, while an asymmetric perturbation
+// shows as the score falling away from 1. A negative number of steps is
+// treated as zero. SymmetryOverTimeUnder measures another symmetry.
+func SymmetryOverTime(g *Generation, rule Rule, steps int) []float64 {
+	return SymmetryOverTimeUnder(g, rule, steps, MirrorSymmetry)
+}
+
+// SymmetryOverTimeUnder is like SymmetryOverTime, but scores each generation
+// against its image under s. The diagonal and quarter turn symmetries only
+// apply to a square board; on any other board they score 0. Under
+// MirrorFlipSymmetry a generation scores the lower of its mirror and flip
+// scores.
+func SymmetryOverTimeUnder(g *Generation, rule Rule, steps int, s Symmetry) []float64 {
+	steps = max(steps, 0)
+	board := g.successor(g.cells)
+	board.rule = rule
+
+	scores := make([]float64, 0, steps+1)
+	for i := 0; ; i++ {
+		score := board.symmetryScore(s)
+		scores = append(scores, score)
+		if i == steps {
+			return scores
+		}
+		board = Next(board)
+	}
+}
+
+// sameCells reports whether g and other have the same dimensions and the same
+// state in every cell
+func (g *Generation) sameCells(other *Generation) bool {
+	if g.dimensions != other.dimensions {
+		return false
+	}
+	for i, c := range g.cells {
+		if c.Alive() != other.cells[i].Alive() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// WithSymmetricRandom configures a generation to be seeded randomly,
+// reproducibly for a given seed, with a board which has the given symmetry:
+// the cells of one part of the board are random and the rest are their
+// mirror images, so MirrorSymmetry mirrors the left half into the right and
+// MirrorFlipSymmetry the top left quadrant into all four. The diagonal and
+// quarter turn symmetries need a square board; on any other board they leave
+// the cells random.
+func WithSymmetricRandom(seed int64, axis Symmetry) Option {
+	return func(g *Generation) {
+		g.generator = &symmetricCellGenerator{
+			d:    &g.dimensions,
+			axis: axis,
+			r:    rand.New(rand.NewSource(seed)),
+		}
+	}
+}
+
+// symmetricCellGenerator reads the dimensions of the generation it seeds when
+// generating, so that it works regardless of the order options are applied
+type symmetricCellGenerator struct {
+	d     *Dimension
+	axis  Symmetry
+	r     *rand.Rand
+	cells []Cell
+}
+
+// Generate copies each cell from the first cell of its orbit under the
+// symmetry, only generating a random cell for the first
+func (g *symmetricCellGenerator) Generate() Cell {
+	idx := len(g.cells)
+	c := NewLiveCell()
+	if first := g.orbitStart(idx); first < idx {
+		c = g.cells[first]
+	} else if g.r.Intn(2) == 0 {
+		c = NewDeadCell()
+	}
+	g.cells = append(g.cells, c)
+
+	return c
+}
+
+// orbitStart returns the lowest index the cell at idx reaches by repeatedly
+// applying the symmetry, or idx itself when the symmetry takes it off the
+// board
+func (g *symmetricCellGenerator) orbitStart(idx int) int {
+	first := idx
+	x, y := idx%g.d.X, idx/g.d.X
+	if g.axis == MirrorFlipSymmetry {
+		// two reflections generate the orbit, so no single map visits it
+		// all: the cell's images in the other three quadrants
+		mx, my := g.d.X-1-x, g.d.Y-1-y
+		return min(first, mx+y*g.d.X, x+my*g.d.X, mx+my*g.d.X)
+	}
+	for {
+		var ok bool
+		if x, y, ok = g.image(x, y); !ok {
+			return idx
+		}
+
+		next := x + y*g.d.X
+		if next == idx {
+			return first
+		}
+		first = min(first, next)
+	}
+}
+
+// image returns where the symmetry takes (x, y), and false if that is off the
+// board
+func (g *symmetricCellGenerator) image(x, y int) (int, int, bool) {
+	w, h := g.d.X, g.d.Y
+	square := w == h
+
+	switch g.axis {
+	case MirrorSymmetry:
+		return w - 1 - x, y, true
+	case FlipSymmetry:
+		return x, h - 1 - y, true
+	case HalfTurnSymmetry:
+		return w - 1 - x, h - 1 - y, true
+	case DiagonalSymmetry:
+		return y, x, square
+	case AntiDiagonalSymmetry:
+		return h - 1 - y, w - 1 - x, square
+	case QuarterTurnSymmetry:
+		return h - 1 - y, x, square
+	default:
+		return x, y, true
+	}
+}
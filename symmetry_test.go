@@ -0,0 +1,176 @@
+package life_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/enocom/life"
+)
+
+func TestSymmetries(t *testing.T) {
+	all := []life.Symmetry{
+		life.MirrorSymmetry,
+		life.FlipSymmetry,
+		life.DiagonalSymmetry,
+		life.AntiDiagonalSymmetry,
+		life.HalfTurnSymmetry,
+		life.QuarterTurnSymmetry,
+	}
+
+	testCases := map[string]struct {
+		board string
+		want  []life.Symmetry
+	}{
+		"glider": {
+			board: ".o.\n..o\nooo\n",
+		},
+		"blinker": {
+			board: ".....\n.ooo.\n.....\n",
+			want:  []life.Symmetry{life.MirrorSymmetry, life.FlipSymmetry, life.HalfTurnSymmetry},
+		},
+		"block": {
+			board: "....\n.oo.\n.oo.\n....\n",
+			want:  all,
+		},
+		"pulsar": {
+			board: "" +
+				"..ooo...ooo..\n" +
+				".............\n" +
+				"o....o.o....o\n" +
+				"o....o.o....o\n" +
+				"o....o.o....o\n" +
+				"..ooo...ooo..\n" +
+				".............\n" +
+				"..ooo...ooo..\n" +
+				"o....o.o....o\n" +
+				"o....o.o....o\n" +
+				"o....o.o....o\n" +
+				".............\n" +
+				"..ooo...ooo..\n",
+			want: all,
+		},
+		"r-pentomino": {
+			board: ".oo\noo.\n.o.\n",
+		},
+		"diagonal only": {
+			board: "oo.\no..\n...\n",
+			want:  []life.Symmetry{life.DiagonalSymmetry},
+		},
+		"empty": {
+			board: "...\n...\n",
+			want:  all,
+		},
+	}
+
+	for description, tc := range testCases {
+		g, err := life.ParseBoard(tc.board)
+		if err != nil {
+			t.Fatalf("(%s): want: no error, got: %v", description, err)
+		}
+		if got := g.Symmetries(); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("(%s): want: %v, got: %v", description, tc.want, got)
+		}
+	}
+}
+
+func TestWithSymmetricRandom(t *testing.T) {
+	testCases := map[string]struct {
+		d    life.Dimension
+		axis life.Symmetry
+	}{
+		"mirror":       {d: life.Dimension{X: 9, Y: 6}, axis: life.MirrorSymmetry},
+		"flip":         {d: life.Dimension{X: 6, Y: 9}, axis: life.FlipSymmetry},
+		"half turn":    {d: life.Dimension{X: 8, Y: 5}, axis: life.HalfTurnSymmetry},
+		"diagonal":     {d: life.Dimension{X: 7, Y: 7}, axis: life.DiagonalSymmetry},
+		"quarter turn": {d: life.Dimension{X: 8, Y: 8}, axis: life.QuarterTurnSymmetry},
+	}
+
+	for description, tc := range testCases {
+		g := life.NewGeneration(life.WithSymmetricRandom(42, tc.axis), life.WithDimension(tc.d))
+
+		grid := g.Grid()
+		w, h := tc.d.X, tc.d.Y
+		for y := range grid {
+			for x := range grid[y] {
+				var mx, my int
+				switch tc.axis {
+				case life.MirrorSymmetry:
+					mx, my = w-1-x, y
+				case life.FlipSymmetry:
+					mx, my = x, h-1-y
+				case life.HalfTurnSymmetry:
+					mx, my = w-1-x, h-1-y
+				case life.DiagonalSymmetry:
+					mx, my = y, x
+				case life.QuarterTurnSymmetry:
+					mx, my = h-1-y, x
+				}
+				if grid[y][x] != grid[my][mx] {
+					t.Fatalf("(%s): want: (%d, %d) to match (%d, %d)\n%v", description, x, y, mx, my, g)
+				}
+			}
+		}
+
+		again := life.NewGeneration(life.WithDimension(tc.d), life.WithSymmetricRandom(42, tc.axis))
+		if !equal(g.Cells(), again.Cells()) {
+			t.Errorf("(%s): want: the same board for the same seed", description)
+		}
+		if g.IsEmpty() {
+			t.Errorf("(%s): want: some live cells", description)
+		}
+	}
+}
+
+func TestSymmetryOverTime(t *testing.T) {
+	d := life.Dimension{X: 16, Y: 16}
+	seed := life.NewGeneration(life.WithDimension(d), life.WithSymmetricRandom(7, life.MirrorSymmetry))
+
+	for i, score := range life.SymmetryOverTime(seed, life.Conway, 20) {
+		if score != 1 {
+			t.Fatalf("generation %d: want: a symmetric seed to stay symmetric, got: %v", i, score)
+		}
+	}
+
+	// toggling one cell breaks the symmetry, both there and in its mirror image
+	perturbed := life.NewGeneration(life.WithDimension(d), life.WithCells(seed.Cells()))
+	perturbed.Set(2, 3, !seed.Cells()[2+3*d.X].Alive())
+	scores := life.SymmetryOverTime(perturbed, life.Conway, 20)
+	if len(scores) != 21 {
+		t.Fatalf("want: 21 scores, got: %v", len(scores))
+	}
+	if want := 1 - 2.0/256; scores[0] != want {
+		t.Errorf("want: %v, got: %v", want, scores[0])
+	}
+
+	wide := newBoard(life.Dimension{X: 4, Y: 2})
+	if got := life.SymmetryOverTimeUnder(wide, life.Conway, 1, life.DiagonalSymmetry); !reflect.DeepEqual(got, []float64{0, 0}) {
+		t.Errorf("want: no diagonal symmetry on a board which is not square, got: %v", got)
+	}
+
+	if got := life.SymmetryOverTime(seed, life.Conway, -3); len(got) != 1 {
+		t.Errorf("want: just the seed's score for negative steps, got: %v", got)
+	}
+}
+
+func TestWithSymmetricRandomBothAxes(t *testing.T) {
+	for _, d := range []life.Dimension{{X: 8, Y: 6}, {X: 9, Y: 7}} {
+		g := life.NewGeneration(life.WithDimension(d), life.WithSymmetricRandom(42, life.MirrorFlipSymmetry))
+
+		if got := g.FlipHorizontal(); !equal(got.Cells(), g.Cells()) {
+			t.Errorf("(%v): want: mirror symmetry, got:\n%v", d, g)
+		}
+		if got := g.FlipVertical(); !equal(got.Cells(), g.Cells()) {
+			t.Errorf("(%v): want: flip symmetry, got:\n%v", d, g)
+		}
+		if g.IsEmpty() {
+			t.Errorf("(%v): want: some live cells", d)
+		}
+	}
+
+	g := life.NewGeneration(life.WithDimension(life.Dimension{X: 8, Y: 6}), life.WithSymmetricRandom(42, life.MirrorFlipSymmetry))
+	for i, score := range life.SymmetryOverTimeUnder(g, life.Conway, 5, life.MirrorFlipSymmetry) {
+		if score != 1 {
+			t.Errorf("generation %d: want: a score of 1, got: %v", i, score)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package life
+
+import (
+	"strings"
+	"text/template"
+	"time"
+)
+
+// FrameData holds the fields available to a template given to
+// WithFrameTemplate
+type FrameData struct {
+	Gen     int           // the number of the generation
+	Pop     int           // the number of live cells
+	Density float64       // the fraction of cells which are alive
+	Rule    string        // the rule in canonical B/S notation
+	Elapsed time.Duration // how long the game has been running
+	Board   string        // the rendered board
+}
+
+// WithFrameTemplate draws each frame by executing t with the frame's
+// FrameData, so any text can surround the board, e.g.
+// "Gen {{.Gen}} | Pop {{.Pop}} | {{.Rule}}\n{{.Board}}". A template which
+// leaves out {{.Board}} draws only the chrome. By default the frame is the
+// board alone. When the template fails, the failure is logged to the logger
+// given to WithLogger and the board is drawn without it.
+func WithFrameTemplate(t *template.Template) GameOption {
+	return func(g *Game) {
+		g.frameTemplate = t
+	}
+}
+
+// applyTemplate returns the frame for the nth generation gen, drawn as
+// board, with the frame template applied
+func (g *Game) applyTemplate(n int, gen *Generation, board string) string {
+	if g.frameTemplate == nil {
+		return board
+	}
+
+	data := FrameData{
+		Gen:     n,
+		Pop:     gen.Population(),
+		Rule:    gen.rule.String(),
+		Elapsed: time.Since(g.started),
+		Board:   board,
+	}
+	if len(gen.cells) > 0 {
+		data.Density = float64(data.Pop) / float64(len(gen.cells))
+	}
+
+	var b strings.Builder
+	if err := g.frameTemplate.Execute(&b, data); err != nil {
+		g.logf("life: frame template for generation %d: %v", n, err)
+		return board
+	}
+
+	return b.String()
+}
@@ -0,0 +1,159 @@
+package life
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Font5x7 is the built in font for TextPattern, with glyphs five cells wide
+// and seven tall for the upper case letters, the digits, the space and a few
+// punctuation marks
+var Font5x7 = map[rune][]string{
+	'A': {".###.", "#...#", "#...#", "#####", "#...#", "#...#", "#...#"},
+	'B': {"####.", "#...#", "#...#", "####.", "#...#", "#...#", "####."},
+	'C': {".###.", "#...#", "#....", "#....", "#....", "#...#", ".###."},
+	'D': {"####.", "#...#", "#...#", "#...#", "#...#", "#...#", "####."},
+	'E': {"#####", "#....", "#....", "####.", "#....", "#....", "#####"},
+	'F': {"#####", "#....", "#....", "####.", "#....", "#....", "#...."},
+	'G': {".###.", "#...#", "#....", "#.###", "#...#", "#...#", ".####"},
+	'H': {"#...#", "#...#", "#...#", "#####", "#...#", "#...#", "#...#"},
+	'I': {".###.", "..#..", "..#..", "..#..", "..#..", "..#..", ".###."},
+	'J': {"..###", "...#.", "...#.", "...#.", "...#.", "#..#.", ".##.."},
+	'K': {"#...#", "#..#.", "#.#..", "##...", "#.#..", "#..#.", "#...#"},
+	'L': {"#....", "#....", "#....", "#....", "#....", "#....", "#####"},
+	'M': {"#...#", "##.##", "#.#.#", "#.#.#", "#...#", "#...#", "#...#"},
+	'N': {"#...#", "#...#", "##..#", "#.#.#", "#..##", "#...#", "#...#"},
+	'O': {".###.", "#...#", "#...#", "#...#", "#...#", "#...#", ".###."},
+	'P': {"####.", "#...#", "#...#", "####.", "#....", "#....", "#...."},
+	'Q': {".###.", "#...#", "#...#", "#...#", "#.#.#", "#..#.", ".##.#"},
+	'R': {"####.", "#...#", "#...#", "####.", "#.#..", "#..#.", "#...#"},
+	'S': {".####", "#....", "#....", ".###.", "....#", "....#", "####."},
+	'T': {"#####", "..#..", "..#..", "..#..", "..#..", "..#..", "..#.."},
+	'U': {"#...#", "#...#", "#...#", "#...#", "#...#", "#...#", ".###."},
+	'V': {"#...#", "#...#", "#...#", "#...#", "#...#", ".#.#.", "..#.."},
+	'W': {"#...#", "#...#", "#...#", "#.#.#", "#.#.#", "#.#.#", ".#.#."},
+	'X': {"#...#", "#...#", ".#.#.", "..#..", ".#.#.", "#...#", "#...#"},
+	'Y': {"#...#", "#...#", ".#.#.", "..#..", "..#..", "..#..", "..#.."},
+	'Z': {"#####", "....#", "...#.", "..#..", ".#...", "#....", "#####"},
+	'0': {".###.", "#...#", "#..##", "#.#.#", "##..#", "#...#", ".###."},
+	'1': {"..#..", ".##..", "..#..", "..#..", "..#..", "..#..", ".###."},
+	'2': {".###.", "#...#", "....#", "...#.", "..#..", ".#...", "#####"},
+	'3': {"#####", "...#.", "..#..", "...#.", "....#", "#...#", ".###."},
+	'4': {"...#.", "..##.", ".#.#.", "#..#.", "#####", "...#.", "...#."},
+	'5': {"#####", "#....", "####.", "....#", "....#", "#...#", ".###."},
+	'6': {"..##.", ".#...", "#....", "####.", "#...#", "#...#", ".###."},
+	'7': {"#####", "....#", "...#.", "..#..", ".#...", ".#...", ".#..."},
+	'8': {".###.", "#...#", "#...#", ".###.", "#...#", "#...#", ".###."},
+	'9': {".###.", "#...#", "#...#", ".####", "....#", "...#.", ".##.."},
+	' ': {".....", ".....", ".....", ".....", ".....", ".....", "....."},
+	'!': {"..#..", "..#..", "..#..", "..#..", "..#..", ".....", "..#.."},
+	'?': {".###.", "#...#", "....#", "...#.", "..#..", ".....", "..#.."},
+	'.': {".....", ".....", ".....", ".....", ".....", ".##..", ".##.."},
+	',': {".....", ".....", ".....", ".....", ".##..", "..#..", ".#..."},
+	'-': {".....", ".....", ".....", "#####", ".....", ".....", "....."},
+	':': {".....", ".##..", ".##..", ".....", ".##..", ".##..", "....."},
+}
+
+// TextPattern renders s as live cells, one glyph from font after another with
+// a dead column between them. Each glyph is a slice of rows in which any
+// character other than '.' or a space is a live cell. Lines of s separated by
+// "\n" are stacked with a dead row between them. A rune missing from the font
+// is drawn in upper case if the font has that, and otherwise as a gap the
+// width of the font's space. A nil font uses Font5x7. The generation is just
+// large enough to hold the text.
+func TextPattern(s string, font map[rune][]string) *Generation {
+	if font == nil {
+		font = Font5x7
+	}
+
+	live := make(map[[2]int]struct{})
+	var d Dimension
+	for i, line := range strings.Split(s, "\n") {
+		if i > 0 {
+			d.Y++
+		}
+
+		x, height := 0, 0
+		for j, r := range []rune(line) {
+			if j > 0 {
+				x++
+			}
+			glyph := lookupGlyph(font, r)
+			for y, row := range glyph {
+				for gx, c := range []rune(row) {
+					if c != '.' && c != ' ' {
+						live[[2]int{x + gx, d.Y + y}] = struct{}{}
+					}
+				}
+			}
+			x += glyphWidth(glyph)
+			height = max(height, len(glyph))
+		}
+
+		d.X = max(d.X, x)
+		d.Y += height
+	}
+
+	g, _ := fromLiveSet(live, d, false)
+	return g
+}
+
+// lookupGlyph returns the glyph font draws r with
+func lookupGlyph(font map[rune][]string, r rune) []string {
+	if glyph, ok := font[r]; ok {
+		return glyph
+	}
+	if glyph, ok := font[unicode.ToUpper(r)]; ok {
+		return glyph
+	}
+
+	// a gap as wide as a space, with no height of its own
+	gap := make([]string, 0, 1)
+	if width := glyphWidth(font[' ']); width > 0 {
+		gap = append(gap, strings.Repeat(".", width))
+	}
+	return gap
+}
+
+// glyphWidth returns the width of the widest row of glyph
+func glyphWidth(glyph []string) int {
+	width := 0
+	for _, row := range glyph {
+		width = max(width, len([]rune(row)))
+	}
+
+	return width
+}
+
+// WithTextSeed configures a generation to be seeded with s rendered by
+// TextPattern in Font5x7, centred on the board, so the text dissolves as the
+// game runs. Text wider or taller than the board is cropped to it.
+func WithTextSeed(s string) Option {
+	return func(g *Generation) {
+		g.generator = &textCellGenerator{board: g, text: TextPattern(s, nil)}
+	}
+}
+
+// textCellGenerator places text in the middle of the board it seeds
+type textCellGenerator struct {
+	board *Generation
+	text  *Generation
+}
+
+// Generate returns a dead cell, as the text is placed by position
+func (t *textCellGenerator) Generate() Cell {
+	return NewDeadCell()
+}
+
+// GenerateAt returns the cell of the text at (x, y), reading the board's
+// dimensions as it is built so options after WithTextSeed still apply
+func (t *textCellGenerator) GenerateAt(x, y int) Cell {
+	d, text := t.board.dimensions, t.text.dimensions
+	x -= (d.X - text.X) / 2
+	y -= (d.Y - text.Y) / 2
+	if x < 0 || y < 0 || x >= text.X || y >= text.Y {
+		return NewDeadCell()
+	}
+
+	return t.text.cells[x+y*text.X]
+}
@@ -0,0 +1,57 @@
+package life_test
+
+import (
+	"testing"
+
+	"github.com/enocom/life"
+)
+
+func TestTextPattern(t *testing.T) {
+	hi := life.TextPattern("HI", nil)
+	if d := (life.Dimension{X: 11, Y: 7}); hi.Dimension() != d {
+		t.Errorf("want: %v, got: %v", d, hi.Dimension())
+	}
+	if got := hi.Population(); got != 28 {
+		t.Errorf("want: 28 live cells, got: %v\n%v", got, hi)
+	}
+	if lower := life.TextPattern("hi", nil); !equal(lower.Cells(), hi.Cells()) {
+		t.Errorf("want: lower case drawn as upper case, got: %v", lower)
+	}
+
+	lines := life.TextPattern("A\nB", nil)
+	if d := (life.Dimension{X: 5, Y: 15}); lines.Dimension() != d {
+		t.Errorf("want: %v, got: %v", d, lines.Dimension())
+	}
+}
+
+func TestTextPatternFont(t *testing.T) {
+	font := map[rune][]string{
+		'+': {".o.", "ooo", ".o."},
+		' ': {"."},
+	}
+
+	got := life.TextPattern("+~+", font)
+	want := newBoard(life.Dimension{X: 9, Y: 3},
+		[2]int{1, 0}, [2]int{0, 1}, [2]int{1, 1}, [2]int{2, 1}, [2]int{1, 2},
+		[2]int{7, 0}, [2]int{6, 1}, [2]int{7, 1}, [2]int{8, 1}, [2]int{7, 2},
+	)
+	if got.Dimension() != want.Dimension() || !equal(got.Cells(), want.Cells()) {
+		t.Errorf("want: %v, got: %v", want, got)
+	}
+}
+
+func TestWithTextSeed(t *testing.T) {
+	g := life.NewGeneration(
+		life.WithTextSeed("HI"),
+		life.WithDimension(life.Dimension{X: 21, Y: 11}),
+	)
+
+	// the I leaves the last column of its glyph dead
+	box, ok := g.BoundingBox()
+	if want := (life.Rect{X: 5, Y: 2, W: 10, H: 7}); !ok || box != want {
+		t.Errorf("want: the text centred at %+v, got: %+v", want, box)
+	}
+	if got := g.Population(); got != 28 {
+		t.Errorf("want: 28 live cells, got: %v", got)
+	}
+}
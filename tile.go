@@ -0,0 +1,35 @@
+package life
+
+// Tile lays out cols×rows copies of pattern separated by gap dead cells,
+// returning a single generation configured like pattern. The board is
+// cols*width+(cols-1)*gap cells wide and likewise tall, with no margin around
+// the outermost copies. A negative gap is treated as zero, so copies never
+// overlap. A mask on pattern is not carried over to the board.
+func Tile(pattern *Generation, cols, rows, gap int) *Generation {
+	p := pattern.dimensions
+	gap = max(gap, 0)
+	if cols < 1 || rows < 1 {
+		cols, rows = 0, 0
+	}
+	d := Dimension{
+		X: max(cols*p.X+(cols-1)*gap, 0),
+		Y: max(rows*p.Y+(rows-1)*gap, 0),
+	}
+
+	cells := make([]Cell, d.X*d.Y)
+	for row := 0; row < rows; row++ {
+		for col := 0; col < cols; col++ {
+			originX, originY := col*(p.X+gap), row*(p.Y+gap)
+			for y := 0; y < p.Y; y++ {
+				start := originX + (originY+y)*d.X
+				copy(cells[start:start+p.X], pattern.cells[y*p.X:(y+1)*p.X])
+			}
+		}
+	}
+
+	tiled := pattern.successor(cells)
+	tiled.dimensions = d
+	tiled.mask = nil
+
+	return tiled
+}
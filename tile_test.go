@@ -0,0 +1,47 @@
+package life_test
+
+import (
+	"testing"
+
+	"github.com/enocom/life"
+)
+
+func TestTile(t *testing.T) {
+	blinker := newBoard(life.Dimension{X: 3, Y: 1}, [2]int{0, 0}, [2]int{1, 0}, [2]int{2, 0})
+
+	tiled := life.Tile(blinker, 2, 2, 1)
+
+	if d := (life.Dimension{X: 7, Y: 3}); tiled.Dimension() != d {
+		t.Errorf("want: %v, got: %v", d, tiled.Dimension())
+	}
+
+	expected := "" +
+		"o o o   o o o\n" +
+		"             \n" +
+		"o o o   o o o\n"
+	if display := tiled.String(); display != expected {
+		t.Errorf("want: %#v, got: %#v", expected, display)
+	}
+
+	if got := tiled.Population(); got != 4*blinker.Population() {
+		t.Errorf("want: %v, got: %v", 4*blinker.Population(), got)
+	}
+}
+
+func TestTileNoCopies(t *testing.T) {
+	block := newBoard(life.Dimension{X: 2, Y: 2}, [2]int{0, 0})
+
+	if d := life.Tile(block, 0, 3, 2).Dimension(); d != (life.Dimension{}) {
+		t.Errorf("want: an empty board, got: %v", d)
+	}
+}
+
+func TestTileNegativeGap(t *testing.T) {
+	blinker := newBoard(life.Dimension{X: 3, Y: 1}, [2]int{0, 0}, [2]int{1, 0}, [2]int{2, 0})
+
+	got := life.Tile(blinker, 2, 1, -2)
+	want := life.Tile(blinker, 2, 1, 0)
+	if got.Dimension() != want.Dimension() || !equal(got.Cells(), want.Cells()) {
+		t.Errorf("want: a negative gap treated as zero, %v, got: %v", want, got)
+	}
+}
@@ -0,0 +1,88 @@
+package life
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"time"
+)
+
+// traceFlushInterval is the longest a trace row waits in the buffer before
+// being written to the trace file
+const traceFlushInterval = time.Second
+
+// WithTraceFile writes a row of timing data for every generation the game
+// produces to a CSV file at path, for profiling where the time goes on large
+// boards:
+//
+//	compute_ns,render_ns,population
+//	48213,120552,61
+//
+// giving the nanoseconds spent computing the generation, the nanoseconds spent
+// drawing it, which is zero for a frame skipped to catch up, and its
+// population. The file is created, or truncated, when the game runs, written
+// out at least once a second and closed when Run returns. Problems with the
+// file are reported to the logger set by WithLogger. By default no trace is
+// written.
+func WithTraceFile(path string) GameOption {
+	return func(g *Game) {
+		g.tracePath = path
+	}
+}
+
+// trace buffers the rows of a trace file
+type trace struct {
+	f         *os.File
+	w         *bufio.Writer
+	lastFlush time.Time
+}
+
+// openTrace creates the trace file, returning nil, which records nothing,
+// when no trace is wanted or the file cannot be created
+func (g *Game) openTrace() *trace {
+	if g.tracePath == "" {
+		return nil
+	}
+
+	f, err := os.Create(g.tracePath)
+	if err != nil {
+		g.logf("life: trace file: %v", err)
+		return nil
+	}
+
+	t := &trace{f: f, w: bufio.NewWriter(f), lastFlush: time.Now()}
+	fmt.Fprintln(t.w, "compute_ns,render_ns,population")
+
+	return t
+}
+
+// record adds a row for a generation which took compute to produce and
+// render to draw, flushing the buffer if it has waited long enough. A nil
+// trace records nothing.
+func (t *trace) record(compute, render time.Duration, gen *Generation) error {
+	if t == nil {
+		return nil
+	}
+
+	fmt.Fprintf(t.w, "%d,%d,%d\n", compute.Nanoseconds(), render.Nanoseconds(), gen.Population())
+	if time.Since(t.lastFlush) < traceFlushInterval {
+		return nil
+	}
+	t.lastFlush = time.Now()
+
+	return t.w.Flush()
+}
+
+// close writes out any buffered rows and closes the file
+func (t *trace) close() error {
+	if t == nil {
+		return nil
+	}
+
+	err := t.w.Flush()
+	if cerr := t.f.Close(); err == nil {
+		err = cerr
+	}
+
+	return err
+}
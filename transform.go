@@ -0,0 +1,81 @@
+package life
+
+// Invert returns a copy of the generation with every cell's state flipped.
+// Cells outside the mask stay dead.
+func (g *Generation) Invert() *Generation {
+	cells := make([]Cell, len(g.cells))
+	for i, c := range g.cells {
+		if !g.masked(i) {
+			cells[i] = Cell{alive: !c.Alive()}
+		}
+	}
+
+	return g.successor(cells)
+}
+
+// Rotate returns a copy of the generation rotated 90 degrees clockwise. The
+// width and height of the board are swapped.
+func (g *Generation) Rotate() *Generation {
+	h := g.dimensions.Y
+	return g.transform(Dimension{X: g.dimensions.Y, Y: g.dimensions.X}, func(x, y int) (int, int) {
+		return y, h - 1 - x
+	})
+}
+
+// FlipHorizontal returns a copy of the generation mirrored left to right
+func (g *Generation) FlipHorizontal() *Generation {
+	w := g.dimensions.X
+	return g.transform(g.dimensions, func(x, y int) (int, int) {
+		return w - 1 - x, y
+	})
+}
+
+// FlipVertical returns a copy of the generation mirrored top to bottom
+func (g *Generation) FlipVertical() *Generation {
+	h := g.dimensions.Y
+	return g.transform(g.dimensions, func(x, y int) (int, int) {
+		return x, h - 1 - y
+	})
+}
+
+// transforms returns the eight rotations and reflections of the generation,
+// starting with the generation itself
+func (g *Generation) transforms() []*Generation {
+	all := make([]*Generation, 0, 8)
+	r := g
+	for i := 0; i < 4; i++ {
+		all = append(all, r, r.FlipHorizontal())
+		r = r.Rotate()
+	}
+
+	return all
+}
+
+// transform returns a generation of size d in which each cell (x, y) is copied
+// from the cell of g at source(x, y). The mask moves with the cells.
+func (g *Generation) transform(d Dimension, source func(x, y int) (int, int)) *Generation {
+	cells := make([]Cell, d.X*d.Y)
+	for y := 0; y < d.Y; y++ {
+		for x := 0; x < d.X; x++ {
+			sx, sy := source(x, y)
+			cells[x+y*d.X] = g.cells[sx+sy*g.dimensions.X]
+		}
+	}
+
+	next := g.successor(cells)
+	next.dimensions = d
+	if g.mask != nil {
+		mask := g.mask
+		next.mask = func(x, y int) bool { return mask(source(x, y)) }
+	}
+
+	return next
+}
+
+// crop returns a copy of the cells of g within r, which must lie within the
+// board
+func (g *Generation) crop(r Rect) *Generation {
+	return g.transform(Dimension{X: r.W, Y: r.H}, func(x, y int) (int, int) {
+		return x + r.X, y + r.Y
+	})
+}
@@ -0,0 +1,64 @@
+package life_test
+
+import (
+	"testing"
+
+	"github.com/enocom/life"
+)
+
+func TestInvert(t *testing.T) {
+	g := newBoard(life.Dimension{X: 3, Y: 2}, [2]int{0, 0}, [2]int{2, 1})
+
+	inverted := g.Invert()
+
+	want := newBoard(life.Dimension{X: 3, Y: 2},
+		[2]int{1, 0}, [2]int{2, 0}, [2]int{0, 1}, [2]int{1, 1},
+	)
+	if !equal(inverted.Cells(), want.Cells()) {
+		t.Errorf("want: %v, got: %v", want.Cells(), inverted.Cells())
+	}
+
+	if inverted.Dimension() != g.Dimension() {
+		t.Errorf("want: %v, got: %v", g.Dimension(), inverted.Dimension())
+	}
+
+	inverted.Cells()[0] = life.NewDeadCell()
+	if !g.Cells()[0].Alive() {
+		t.Error("want: inverted generation not to alias the original")
+	}
+}
+
+func TestRotateAndFlip(t *testing.T) {
+	// o o o
+	// o - -
+	g := newBoard(life.Dimension{X: 3, Y: 2}, [2]int{0, 0}, [2]int{1, 0}, [2]int{2, 0}, [2]int{0, 1})
+
+	testCases := map[string]struct {
+		got  *life.Generation
+		want string
+	}{
+		"Rotate":         {got: g.Rotate(), want: "o o\n  o\n  o\n"},
+		"FlipHorizontal": {got: g.FlipHorizontal(), want: "o o o\n    o\n"},
+		"FlipVertical":   {got: g.FlipVertical(), want: "o    \no o o\n"},
+		"four rotations": {got: g.Rotate().Rotate().Rotate().Rotate(), want: g.String()},
+	}
+
+	for description, tc := range testCases {
+		if display := tc.got.String(); display != tc.want {
+			t.Errorf("(%s): want %#v, got %#v", description, tc.want, display)
+		}
+	}
+}
+
+func TestInvertMasked(t *testing.T) {
+	d := life.Dimension{X: 2, Y: 2}
+	g := life.NewGeneration(
+		life.WithDimension(d),
+		life.WithCells(newBoard(d).Cells()),
+		life.WithMask(func(x, y int) bool { return x == 0 }),
+	)
+
+	if want := newBoard(d, [2]int{0, 0}, [2]int{0, 1}); !equal(g.Invert().Cells(), want.Cells()) {
+		t.Errorf("want: %v, got: %v", want, g.Invert())
+	}
+}
@@ -0,0 +1,55 @@
+package life
+
+// watcherBuffer is how many generations a watcher may fall behind by before
+// the oldest are dropped for it
+const watcherBuffer = 16
+
+// subscribe returns a channel which receives each generation the game
+// produces from now on, starting with the current one if the game has
+// started. The channel must be passed to unsubscribe once it is no longer
+// read.
+func (g *Game) subscribe() chan snapshot {
+	c := make(chan snapshot, watcherBuffer)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.watchers == nil {
+		g.watchers = make(map[chan snapshot]struct{})
+	}
+	g.watchers[c] = struct{}{}
+	if g.current != nil {
+		c <- snapshot{n: g.currentN, gen: g.current}
+	}
+
+	return c
+}
+
+// unsubscribe stops sending generations to a channel returned by subscribe
+func (g *Game) unsubscribe(c chan snapshot) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	delete(g.watchers, c)
+}
+
+// publish sends gen, the nth generation, to every watcher. A slow watcher
+// misses generations rather than holding up the game: when it has no room,
+// the oldest generation waiting for it is dropped, so the latest always
+// arrives. The caller holds g.mu.
+func (g *Game) publish(n int, gen *Generation) {
+	for c := range g.watchers {
+		select {
+		case c <- snapshot{n: n, gen: gen}:
+			continue
+		default:
+		}
+
+		// only publish sends, and under g.mu, so taking one out makes room
+		select {
+		case <-c:
+		default:
+		}
+		c <- snapshot{n: n, gen: gen}
+	}
+}